@@ -0,0 +1,104 @@
+// Package apperr defines the errors resolvers return to clients. Each error
+// carries a code that the servers render into errors[].extensions.code, so
+// clients can tell a missing object from a bad argument without parsing
+// messages.
+//
+// Errors without a code are internal: their message may expose details of
+// the implementation, so they are logged and replaced by a generic message
+// before reaching the client.
+package apperr
+
+import (
+	"errors"
+	"fmt"
+	"log"
+)
+
+// Code classifies an error for clients.
+type Code string
+
+const (
+	// NotFound means the object asked for does not exist.
+	NotFound Code = "NOT_FOUND"
+	// BadUserInput means an argument is malformed or out of range.
+	BadUserInput Code = "BAD_USER_INPUT"
+	// Internal means the server failed, whatever the request was.
+	Internal Code = "INTERNAL"
+	// SlowConsumer means a subscription fell too far behind its events and
+	// was ended.
+	SlowConsumer Code = "SLOW_CONSUMER"
+)
+
+// internalMessage replaces the message of internal errors sent to clients.
+const internalMessage = "internal server error"
+
+// Error is an error with a code. Its message is shown to clients unless the
+// code is Internal.
+type Error struct {
+	Code    Code
+	Message string
+	// Err is the underlying error, if any. It is never shown to clients.
+	Err error
+	// Field is the path to the input field at fault from the arguments of
+	// the field that failed, such as ["review", "stars"], rendered as the
+	// field extension. It is nil for errors not about one input field.
+	Field []interface{}
+}
+
+// NotFoundf returns a NotFound error with a formatted message.
+func NotFoundf(format string, args ...interface{}) *Error {
+	return &Error{Code: NotFound, Message: fmt.Sprintf(format, args...)}
+}
+
+// BadUserInputf returns a BadUserInput error with a formatted message.
+func BadUserInputf(format string, args ...interface{}) *Error {
+	return &Error{Code: BadUserInput, Message: fmt.Sprintf(format, args...)}
+}
+
+// Internalf returns an Internal error with a formatted message, which is
+// logged but not shown to clients.
+func Internalf(format string, args ...interface{}) *Error {
+	return &Error{Code: Internal, Message: fmt.Sprintf(format, args...)}
+}
+
+func (e *Error) Error() string {
+	if e.Err != nil {
+		return e.Message + ": " + e.Err.Error()
+	}
+	return e.Message
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Extensions returns the extensions of the error in a GraphQL response.
+func (e *Error) Extensions() map[string]interface{} {
+	extensions := map[string]interface{}{"code": string(e.Code)}
+	if e.Field != nil {
+		extensions["field"] = e.Field
+	}
+	return extensions
+}
+
+// CodeOf returns the code of the first Error in the chain of err, and
+// Internal if there is none.
+func CodeOf(err error) Code {
+	var e *Error
+	if errors.As(err, &e) {
+		return e.Code
+	}
+	return Internal
+}
+
+// Present returns the error to show a client in place of err. Errors with a
+// code other than Internal are returned as they are; anything else is logged
+// and replaced by a generic Internal error.
+func Present(err error) *Error {
+	var e *Error
+	if errors.As(err, &e) && e.Code != Internal {
+		return e
+	}
+	log.Printf("internal error: %v", err)
+	return &Error{Code: Internal, Message: internalMessage}
+}
@@ -0,0 +1,29 @@
+package apperr
+
+import (
+	"strings"
+
+	"github.com/graph-gophers/graphql-go/errors"
+)
+
+// panicPrefix starts the message of the errors graph-gophers reports for a
+// resolver that panicked.
+const panicPrefix = "panic occurred: "
+
+// PresentQueryErrors rewrites the errors of a graph-gophers response in
+// place. Errors returned by resolvers and panics are replaced by what Present
+// makes of them; syntax and validation errors are kept as they are.
+func PresentQueryErrors(errs []*errors.QueryError) {
+	for _, err := range errs {
+		cause := err.ResolverError
+		if cause == nil {
+			if !strings.HasPrefix(err.Message, panicPrefix) {
+				continue
+			}
+			cause = err
+		}
+		e := Present(cause)
+		err.Message = e.Message
+		err.Extensions = e.Extensions()
+	}
+}
@@ -0,0 +1,28 @@
+package apperr
+
+import (
+	"context"
+	"errors"
+
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+// ErrorPresenter renders errors in a gqlgen handler, set with
+// SetErrorPresenter. Errors built as a *gqlerror.Error on purpose, such as
+// validation errors, are kept as they are.
+func ErrorPresenter(ctx context.Context, err error) *gqlerror.Error {
+	var gqlErr *gqlerror.Error
+	if errors.As(err, &gqlErr) && gqlErr.Unwrap() == nil {
+		return gqlErr
+	}
+	e := Present(err)
+	presented := &gqlerror.Error{
+		Message:    e.Message,
+		Extensions: e.Extensions(),
+	}
+	if gqlErr != nil {
+		presented.Path = gqlErr.Path
+		presented.Locations = gqlErr.Locations
+	}
+	return presented
+}
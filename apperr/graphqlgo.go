@@ -0,0 +1,42 @@
+package apperr
+
+import (
+	"github.com/graphql-go/graphql/gqlerrors"
+)
+
+// FormatError renders errors in a graphql-go handler, set as its
+// FormatErrorFn. Syntax and validation errors are kept as they are.
+func FormatError(err error) gqlerrors.FormattedError {
+	located, ok := err.(*gqlerrors.Error)
+	if !ok || located.OriginalError == nil {
+		return gqlerrors.FormatError(err)
+	}
+	e := Present(cause(located.OriginalError))
+	return gqlerrors.FormattedError{
+		Message:    e.Message,
+		Locations:  located.Locations,
+		Path:       located.Path,
+		Extensions: e.Extensions(),
+	}
+}
+
+// cause returns the error a resolver returned or panicked with. graphql-go
+// wraps it once more every time it bubbles up to a nullable parent.
+func cause(err error) error {
+	for {
+		switch e := err.(type) {
+		case *gqlerrors.Error:
+			if e.OriginalError == nil {
+				return e
+			}
+			err = e.OriginalError
+		case gqlerrors.FormattedError:
+			if e.OriginalError() == nil {
+				return e
+			}
+			err = e.OriginalError()
+		default:
+			return err
+		}
+	}
+}
@@ -0,0 +1,523 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"go/token"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/formatter"
+)
+
+// generator accumulates the declarations of a client.
+type generator struct {
+	schema  *ast.Schema
+	doc     *ast.QueryDocument
+	scalars scalarTypes
+
+	imports map[string]bool
+	funcs   bytes.Buffer
+	types   *bytes.Buffer
+	enums   map[string]bool
+	inputs  map[string]bool
+	err     error
+}
+
+// selection is a selection set flattened for a parent type: the fields
+// selected whatever the type of the object, and the groups of fields
+// selected by fragments on narrower types.
+type selection struct {
+	parent *ast.Definition
+	fields []*ast.Field
+	groups []*group
+}
+
+// group is the fields selected when an object is of type cond.
+type group struct {
+	cond *ast.Definition
+	sel  *selection
+}
+
+// generate returns the Go source of a client of the operations of doc.
+func generate(schema *ast.Schema, doc *ast.QueryDocument, pkg string, scalars scalarTypes) ([]byte, error) {
+	g := &generator{
+		schema:  schema,
+		doc:     doc,
+		scalars: scalars,
+		imports: map[string]bool{"context": true, "graphql/gqlclient": true},
+		types:   &bytes.Buffer{},
+		enums:   map[string]bool{},
+		inputs:  map[string]bool{},
+	}
+	for _, op := range doc.Operations {
+		g.addTypename(op.SelectionSet)
+	}
+	for _, f := range doc.Fragments {
+		g.addTypename(f.SelectionSet)
+	}
+	for _, op := range doc.Operations {
+		g.operation(op)
+	}
+	g.inputTypes()
+	g.enumTypes()
+	if g.err != nil {
+		return nil, g.err
+	}
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "// Code generated by clientgen. DO NOT EDIT.\n\npackage %s\n\nimport (\n", pkg)
+	var imports []string
+	for i := range g.imports {
+		imports = append(imports, i)
+	}
+	sort.Strings(imports)
+	for _, i := range imports {
+		fmt.Fprintf(&b, "%q\n", i)
+	}
+	b.WriteString(")\n")
+	b.Write(g.funcs.Bytes())
+	b.Write(g.types.Bytes())
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("formatting the generated code: %v", err)
+	}
+	return src, nil
+}
+
+func (g *generator) fail(format string, args ...interface{}) {
+	if g.err == nil {
+		g.err = fmt.Errorf(format, args...)
+	}
+}
+
+// addTypename selects __typename in the selection sets of interfaces and
+// unions, which tells the client the fragments that apply.
+func (g *generator) addTypename(set ast.SelectionSet) {
+	for _, sel := range set {
+		switch sel := sel.(type) {
+		case *ast.Field:
+			if len(sel.SelectionSet) == 0 {
+				continue
+			}
+			if g.schema.Types[sel.Definition.Type.Name()].IsAbstractType() && !selectsTypename(sel.SelectionSet) {
+				typename := &ast.Field{Alias: "__typename", Name: "__typename"}
+				sel.SelectionSet = append(ast.SelectionSet{typename}, sel.SelectionSet...)
+			}
+			g.addTypename(sel.SelectionSet)
+		case *ast.InlineFragment:
+			g.addTypename(sel.SelectionSet)
+		}
+	}
+}
+
+func selectsTypename(set ast.SelectionSet) bool {
+	for _, sel := range set {
+		if f, ok := sel.(*ast.Field); ok && f.Alias == "__typename" && f.Name == "__typename" {
+			return true
+		}
+	}
+	return false
+}
+
+func (g *generator) operation(op *ast.OperationDefinition) {
+	if op.Name == "" {
+		g.fail("operations must be named")
+		return
+	}
+	var root *ast.Definition
+	switch op.Operation {
+	case ast.Query:
+		root = g.schema.Query
+	case ast.Mutation:
+		root = g.schema.Mutation
+	default:
+		g.fail("%s: %s operations are not supported", op.Name, op.Operation)
+		return
+	}
+	name := goName(op.Name)
+	queryConst := lowerFirst(name) + "Query"
+	g.object(name+"Response", name, g.collect(root, op.SelectionSet))
+
+	var text bytes.Buffer
+	formatter.NewFormatter(&text).FormatQueryDocument(&ast.QueryDocument{
+		Operations: ast.OperationList{op},
+		Fragments:  g.fragments(op.SelectionSet, map[string]bool{}),
+	})
+	query := "`" + text.String() + "`"
+	if strings.Contains(text.String(), "`") {
+		query = strconv.Quote(text.String())
+	}
+	fmt.Fprintf(&g.funcs, "\nconst %s = %s\n", queryConst, query)
+
+	// Variables left nil are not sent, so that the server applies their
+	// default value rather than null.
+	var params, required, optional []string
+	for _, v := range op.VariableDefinitions {
+		p := paramName(v.Variable)
+		typ := g.inputType(v.Type, v.DefaultValue != nil)
+		params = append(params, p+" "+typ)
+		if strings.HasPrefix(typ, "*") || strings.HasPrefix(typ, "[]") {
+			optional = append(optional, fmt.Sprintf("if %s != nil {\nvariables[%q] = %s\n}\n", p, v.Variable, p))
+		} else {
+			required = append(required, fmt.Sprintf("%q: %s,\n", v.Variable, p))
+		}
+	}
+	fmt.Fprintf(&g.funcs, "\n// %s sends the %s %s.\n", name, op.Name, op.Operation)
+	fmt.Fprintf(&g.funcs, "func %s(ctx context.Context, client *gqlclient.Client", name)
+	for _, p := range params {
+		g.funcs.WriteString(", " + p)
+	}
+	fmt.Fprintf(&g.funcs, ") (*%sResponse, error) {\n", name)
+	variables := "nil"
+	if len(params) > 0 {
+		variables = "variables"
+		fmt.Fprintf(&g.funcs, "variables := map[string]interface{}{\n%s}\n%s", strings.Join(required, ""), strings.Join(optional, ""))
+	}
+	fmt.Fprintf(&g.funcs, "var data %sResponse\n", name)
+	fmt.Fprintf(&g.funcs, "err := client.Do(ctx, %q, %s, %s, &data)\n", op.Name, queryConst, variables)
+	g.funcs.WriteString("return &data, err\n}\n")
+}
+
+// fragments returns the fragment definitions used by set, directly or not,
+// by name.
+func (g *generator) fragments(set ast.SelectionSet, seen map[string]bool) ast.FragmentDefinitionList {
+	var l ast.FragmentDefinitionList
+	for _, sel := range set {
+		switch sel := sel.(type) {
+		case *ast.Field:
+			l = append(l, g.fragments(sel.SelectionSet, seen)...)
+		case *ast.InlineFragment:
+			l = append(l, g.fragments(sel.SelectionSet, seen)...)
+		case *ast.FragmentSpread:
+			if seen[sel.Name] {
+				continue
+			}
+			seen[sel.Name] = true
+			def := g.doc.Fragments.ForName(sel.Name)
+			l = append(l, def)
+			l = append(l, g.fragments(def.SelectionSet, seen)...)
+		}
+	}
+	sort.Slice(l, func(i, j int) bool { return l[i].Name < l[j].Name })
+	return l
+}
+
+// collect flattens set, selected on an object of type parent.
+func (g *generator) collect(parent *ast.Definition, set ast.SelectionSet) *selection {
+	s := &selection{parent: parent}
+	g.collectInto(s, parent, set)
+	return s
+}
+
+func (g *generator) collectInto(s *selection, parent *ast.Definition, set ast.SelectionSet) {
+	for _, sel := range set {
+		switch sel := sel.(type) {
+		case *ast.Field:
+			s.addField(sel)
+		case *ast.InlineFragment:
+			cond := sel.TypeCondition
+			if cond == "" {
+				cond = parent.Name
+			}
+			g.fragment(s, parent, cond, sel.SelectionSet)
+		case *ast.FragmentSpread:
+			g.fragment(s, parent, sel.Definition.TypeCondition, sel.Definition.SelectionSet)
+		}
+	}
+}
+
+// fragment adds the fields of a fragment on cond to s: to its fields if
+// the fragment applies to every object of type parent, and to the group of
+// cond otherwise.
+func (g *generator) fragment(s *selection, parent *ast.Definition, cond string, set ast.SelectionSet) {
+	def := g.schema.Types[cond]
+	if def.Name == parent.Name || parent.Kind == ast.Object || g.covers(def, parent) {
+		g.collectInto(s, parent, set)
+		return
+	}
+	for _, grp := range s.groups {
+		if grp.cond.Name == def.Name {
+			g.collectInto(grp.sel, def, set)
+			return
+		}
+	}
+	s.groups = append(s.groups, &group{cond: def, sel: g.collect(def, set)})
+}
+
+// covers tells whether every object of type parent is of type cond.
+func (g *generator) covers(cond, parent *ast.Definition) bool {
+	for _, t := range g.schema.GetPossibleTypes(parent) {
+		if !g.isPossible(cond, t.Name) {
+			return false
+		}
+	}
+	return true
+}
+
+func (g *generator) isPossible(def *ast.Definition, name string) bool {
+	for _, t := range g.schema.GetPossibleTypes(def) {
+		if t.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// addField adds f to the fields of s, merging the selection sets of the
+// fields with the same response name.
+func (s *selection) addField(f *ast.Field) {
+	for i, other := range s.fields {
+		if other.Alias == f.Alias {
+			merged := *other
+			merged.SelectionSet = append(append(ast.SelectionSet{}, other.SelectionSet...), f.SelectionSet...)
+			s.fields[i] = &merged
+			return
+		}
+	}
+	s.fields = append(s.fields, f)
+}
+
+// object declares the struct name of the selection s, naming the structs
+// of its fields after prefix.
+func (g *generator) object(name, prefix string, s *selection) {
+	// The structs of the fields are declared after this one.
+	outer := g.types
+	g.types = &bytes.Buffer{}
+	defer func(nested *bytes.Buffer) {
+		g.types = outer
+		g.types.Write(nested.Bytes())
+	}(g.types)
+
+	var fields bytes.Buffer
+	typename := false
+	for _, f := range s.fields {
+		if f.Alias == "__typename" {
+			typename = true
+		}
+		fmt.Fprintf(&fields, "%s %s `json:%q`\n", goName(f.Alias), g.outputType(prefix+goName(f.Alias), f), f.Alias)
+	}
+	if len(s.groups) > 0 && !typename {
+		// The fields of a fragment on an interface can themselves be split
+		// by type, the __typename selected next to the fragment tells how.
+		fields.WriteString("Typename string `json:\"__typename\"`\n")
+	}
+	for _, grp := range s.groups {
+		fmt.Fprintf(&fields, "On%s *%sOn%s `json:\"-\"`\n", grp.cond.Name, prefix, grp.cond.Name)
+	}
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "\ntype %s struct {\n%s}\n", name, fields.Bytes())
+	if len(s.groups) > 0 {
+		g.imports["encoding/json"] = true
+		fmt.Fprintf(&b, "\nfunc (x *%s) UnmarshalJSON(b []byte) error {\n", name)
+		fmt.Fprintf(&b, "type plain %s\nif err := json.Unmarshal(b, (*plain)(x)); err != nil {\nreturn err\n}\n", name)
+		for _, grp := range s.groups {
+			var cases []string
+			for _, t := range g.schema.GetPossibleTypes(grp.cond) {
+				if g.isPossible(s.parent, t.Name) {
+					cases = append(cases, strconv.Quote(t.Name))
+				}
+			}
+			sort.Strings(cases)
+			field := "x.On" + grp.cond.Name
+			fmt.Fprintf(&b, "switch x.Typename {\ncase %s:\n", strings.Join(cases, ", "))
+			fmt.Fprintf(&b, "%s = new(%sOn%s)\nif err := json.Unmarshal(b, %s); err != nil {\nreturn err\n}\n}\n", field, prefix, grp.cond.Name, field)
+		}
+		b.WriteString("return nil\n}\n")
+	}
+	outer.Write(b.Bytes())
+
+	for _, grp := range s.groups {
+		groupName := prefix + "On" + grp.cond.Name
+		g.object(groupName, groupName, grp.sel)
+	}
+}
+
+// outputType returns the Go type of the field f, declaring the struct name
+// if it selects fields of an object.
+func (g *generator) outputType(name string, f *ast.Field) string {
+	if f.Definition == nil {
+		// __typename added by addTypename, which was not validated.
+		return "string"
+	}
+	return g.goType(f.Definition.Type, false, func(def *ast.Definition) string {
+		switch def.Kind {
+		case ast.Object, ast.Interface, ast.Union:
+			g.object(name, name, g.collect(def, f.SelectionSet))
+			return name
+		}
+		return g.namedType(def)
+	})
+}
+
+// inputType returns the Go type of a variable or input field of type t,
+// which is a pointer if the value can be left out.
+func (g *generator) inputType(t *ast.Type, hasDefault bool) string {
+	return g.goType(t, hasDefault, g.namedType)
+}
+
+func (g *generator) goType(t *ast.Type, optional bool, named func(*ast.Definition) string) string {
+	if t.Elem != nil {
+		return "[]" + g.goType(t.Elem, false, named)
+	}
+	def := g.schema.Types[t.NamedType]
+	if def == nil {
+		g.fail("unknown type %s", t.NamedType)
+		return ""
+	}
+	typ := named(def)
+	if !t.NonNull || optional {
+		typ = "*" + typ
+	}
+	return typ
+}
+
+// namedType returns the Go type of a scalar, enum or input type.
+func (g *generator) namedType(def *ast.Definition) string {
+	switch def.Kind {
+	case ast.Scalar:
+		typ, ok := g.scalars[def.Name]
+		if !ok {
+			g.fail("no Go type for scalar %s, map it with -scalar", def.Name)
+			return ""
+		}
+		i := strings.LastIndex(typ, ".")
+		if i < 0 {
+			return typ
+		}
+		g.imports[typ[:i]] = true
+		return path.Base(typ[:i]) + typ[i:]
+	case ast.Enum:
+		g.enums[def.Name] = true
+	case ast.InputObject:
+		g.inputs[def.Name] = true
+	}
+	return goName(def.Name)
+}
+
+// inputTypes declares the input types used by the operations, and those
+// they use in turn.
+func (g *generator) inputTypes() {
+	declared := map[string]bool{}
+	for {
+		var pending []string
+		for name := range g.inputs {
+			if !declared[name] {
+				pending = append(pending, name)
+			}
+		}
+		if len(pending) == 0 {
+			return
+		}
+		sort.Strings(pending)
+		for _, name := range pending {
+			declared[name] = true
+			def := g.schema.Types[name]
+			fmt.Fprintf(g.types, "\n// %s is the input type %s.\ntype %s struct {\n", goName(name), name, goName(name))
+			for _, f := range def.Fields {
+				optional := !f.Type.NonNull || f.DefaultValue != nil
+				tag := f.Name
+				if optional {
+					tag += ",omitempty"
+				}
+				fmt.Fprintf(g.types, "%s %s `json:%q`\n", goName(f.Name), g.inputType(f.Type, f.DefaultValue != nil), tag)
+			}
+			g.types.WriteString("}\n")
+		}
+	}
+}
+
+// enumTypes declares the enums used by the operations, as strings with a
+// constant per value.
+func (g *generator) enumTypes() {
+	var names []string
+	for name := range g.enums {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		typ := goName(name)
+		fmt.Fprintf(g.types, "\n// %s is the enum %s.\ntype %s string\n\nconst (\n", typ, name, typ)
+		for _, v := range g.schema.Types[name].EnumValues {
+			fmt.Fprintf(g.types, "%s%s %s = %q\n", typ, goName(v.Name), typ, v.Name)
+		}
+		g.types.WriteString(")\n")
+	}
+}
+
+// initialisms are the words written in capitals in Go names.
+var initialisms = map[string]string{
+	"id":   "ID",
+	"ids":  "IDs",
+	"url":  "URL",
+	"http": "HTTP",
+	"json": "JSON",
+}
+
+// goName returns the exported Go name of a GraphQL name, such as AppearsIn
+// for appearsIn, ID for id and NewHope for NEW_HOPE.
+func goName(name string) string {
+	var b strings.Builder
+	for _, w := range words(name) {
+		if initialism, ok := initialisms[strings.ToLower(w)]; ok {
+			b.WriteString(initialism)
+			continue
+		}
+		r := []rune(strings.ToLower(w))
+		if w != strings.ToUpper(w) {
+			// Keep the case of mixed-case words, such as the Details
+			// of HumanDetails.
+			r = []rune(w)
+		}
+		r[0] = unicode.ToUpper(r[0])
+		b.WriteString(string(r))
+	}
+	return b.String()
+}
+
+// words splits a GraphQL name at underscores and at the start of each
+// capitalized word.
+func words(name string) []string {
+	var l []string
+	for _, part := range strings.Split(name, "_") {
+		start := 0
+		r := []rune(part)
+		for i := 1; i < len(r); i++ {
+			if unicode.IsUpper(r[i]) && !unicode.IsUpper(r[i-1]) {
+				l = append(l, string(r[start:i]))
+				start = i
+			}
+		}
+		if start < len(r) {
+			l = append(l, string(r[start:]))
+		}
+	}
+	return l
+}
+
+// paramName returns the name of the parameter of a variable.
+func paramName(variable string) string {
+	name := goName(variable)
+	first := words(variable)[0]
+	if initialism, ok := initialisms[strings.ToLower(first)]; ok {
+		name = strings.ToLower(initialism) + name[len(initialism):]
+	} else {
+		name = lowerFirst(name)
+	}
+	if token.Lookup(name).IsKeyword() || name == "ctx" || name == "client" {
+		name += "Arg"
+	}
+	return name
+}
+
+func lowerFirst(s string) string {
+	r := []rune(s)
+	r[0] = unicode.ToLower(r[0])
+	return string(r)
+}
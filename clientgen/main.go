@@ -0,0 +1,110 @@
+// Command clientgen generates a typed Go client from an SDL schema and a
+// directory of .graphql files holding the operations the client sends and
+// the fragments they use.
+//
+// Each operation becomes a function taking its variables and returning a
+// struct of its data. Selections on an interface or a union get a
+// __typename field and, for each type condition of their fragments, an On
+// field that is set when the object is of that type. Custom scalars are
+// mapped to Go types with -scalar:
+//
+//	go run ./clientgen -schema gqlgen-starwar/schema.graphql -operations gqlgen-starwar/client/operations \
+//		-o gqlgen-starwar/client/client.go -package client -scalar Time=time.Time
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/vektah/gqlparser/v2"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+var (
+	schemaFile = flag.String("schema", "", "SDL file of the schema")
+	operations = flag.String("operations", "", "directory of the .graphql operation and fragment files")
+	output     = flag.String("o", "client.go", "Go file written")
+	pkg        = flag.String("package", "client", "package of the generated file")
+	scalars    = scalarTypes{
+		"String":  "string",
+		"Int":     "int",
+		"Float":   "float64",
+		"Boolean": "bool",
+		"ID":      "string",
+	}
+)
+
+func init() {
+	flag.Var(scalars, "scalar", "Go type of a custom scalar as Name=[import/path.]Type, may be repeated")
+}
+
+func main() {
+	flag.Parse()
+	log.SetFlags(0)
+	if *schemaFile == "" || *operations == "" {
+		log.Fatal("usage: clientgen -schema schema.graphql -operations dir [-o client.go] [-package client] [-scalar Name=Type]")
+	}
+
+	sdl, err := ioutil.ReadFile(*schemaFile)
+	if err != nil {
+		log.Fatal(err)
+	}
+	schema, gqlErr := gqlparser.LoadSchema(&ast.Source{Name: *schemaFile, Input: string(sdl)})
+	if gqlErr != nil {
+		log.Fatal(gqlErr)
+	}
+
+	files, err := filepath.Glob(filepath.Join(*operations, "*.graphql"))
+	if err != nil {
+		log.Fatal(err)
+	}
+	sort.Strings(files)
+	var sources []string
+	for _, file := range files {
+		b, err := ioutil.ReadFile(file)
+		if err != nil {
+			log.Fatal(err)
+		}
+		sources = append(sources, string(b))
+	}
+	// The files are validated as one document so that fragments can be
+	// shared between them.
+	doc, errs := gqlparser.LoadQuery(schema, strings.Join(sources, "\n"))
+	if len(errs) > 0 {
+		log.Fatal(errs)
+	}
+
+	src, err := generate(schema, doc, *pkg, scalars)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := ioutil.WriteFile(*output, src, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// scalarTypes are the Go types of scalars, by scalar name.
+type scalarTypes map[string]string
+
+func (s scalarTypes) String() string {
+	var l []string
+	for name, typ := range s {
+		l = append(l, name+"="+typ)
+	}
+	sort.Strings(l)
+	return strings.Join(l, ",")
+}
+
+func (s scalarTypes) Set(v string) error {
+	parts := strings.SplitN(v, "=", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return fmt.Errorf("scalar %q is not in the form Name=Type", v)
+	}
+	s[parts[0]] = parts[1]
+	return nil
+}
@@ -0,0 +1,198 @@
+package conformance
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"graphql/dataset"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+)
+
+var (
+	corpus    = flag.String("corpus", "testdata", "directory of the operations and golden outputs")
+	fixture   = flag.String("dataset", "", "JSON or YAML fixture of the Star Wars data served, the embedded one if empty")
+	update    = flag.Bool("update", false, "rewrite the golden outputs from the reference implementation")
+	reference = flag.String("reference", "gqlgen", "implementation whose responses -update records")
+)
+
+// operation is one request of the corpus.
+type operation struct {
+	name      string
+	query     string
+	variables map[string]interface{}
+	golden    interface{}
+}
+
+// TestConformance sends every operation of the corpus to every
+// implementation, in order, and compares the responses with the golden
+// outputs, in a subtest per implementation and operation.
+func TestConformance(t *testing.T) {
+	ds, err := dataset.Open(*fixture)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ops, err := loadCorpus(*corpus)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ops) == 0 {
+		t.Fatalf("no operations in %s", *corpus)
+	}
+	if *update {
+		if err := updateGolden(ds, ops); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, impl := range implementations {
+		t.Run(impl.name, func(t *testing.T) {
+			// The operations all run, whatever the subtests selected, as the
+			// mutations of the corpus are seen by the operations after them.
+			divergences, err := check(impl, ds, ops)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, op := range ops {
+				t.Run(op.name, func(t *testing.T) {
+					for _, d := range divergences[op.name] {
+						t.Error(d)
+					}
+				})
+			}
+		})
+	}
+}
+
+// loadCorpus reads the operations of dir in the order of their names.
+func loadCorpus(dir string) ([]operation, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.graphql"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(files)
+
+	var ops []operation
+	for _, file := range files {
+		name := strings.TrimSuffix(filepath.Base(file), ".graphql")
+		query, err := ioutil.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		op := operation{name: name, query: string(query)}
+		if err := readJSON(filepath.Join(dir, name+".variables.json"), &op.variables); err != nil {
+			return nil, err
+		}
+		if err := readJSON(filepath.Join(dir, name+".golden.json"), &op.golden); err != nil {
+			return nil, err
+		}
+		ops = append(ops, op)
+	}
+	return ops, nil
+}
+
+// readJSON decodes file into v, leaving v untouched if file does not exist.
+func readJSON(file string, v interface{}) error {
+	b, err := ioutil.ReadFile(file)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(b, v); err != nil {
+		return fmt.Errorf("%s: %v", file, err)
+	}
+	return nil
+}
+
+// updateGolden records the responses of the reference implementation as the
+// golden outputs of ops.
+func updateGolden(ds *dataset.Dataset, ops []operation) error {
+	for _, impl := range implementations {
+		if impl.name != *reference {
+			continue
+		}
+		responses, err := execute(impl, ds, ops)
+		if err != nil {
+			return err
+		}
+		for i := range ops {
+			ops[i].golden = responses[i]
+			b, err := json.MarshalIndent(responses[i], "", "  ")
+			if err != nil {
+				return err
+			}
+			file := filepath.Join(*corpus, ops[i].name+".golden.json")
+			if err := ioutil.WriteFile(file, append(b, '\n'), 0644); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return fmt.Errorf("unknown reference implementation %q", *reference)
+}
+
+// check runs ops against impl and returns the divergences from the golden
+// outputs, keyed by operation name.
+func check(impl implementation, ds *dataset.Dataset, ops []operation) (map[string][]string, error) {
+	responses, err := execute(impl, ds, ops)
+	if err != nil {
+		return nil, err
+	}
+	divergences := map[string][]string{}
+	for i, op := range ops {
+		if op.golden == nil {
+			divergences[op.name] = append(divergences[op.name], "no golden output, run with -update")
+			continue
+		}
+		diff("", op.golden, responses[i], func(format string, args ...interface{}) {
+			divergences[op.name] = append(divergences[op.name], fmt.Sprintf(format, args...))
+		})
+	}
+	return divergences, nil
+}
+
+// execute starts a server of impl serving ds and sends it ops in order, so
+// that the mutations of the corpus are seen by the operations after them.
+func execute(impl implementation, ds *dataset.Dataset, ops []operation) ([]interface{}, error) {
+	h, err := impl.handler(ds)
+	if err != nil {
+		return nil, err
+	}
+	srv := httptest.NewServer(h)
+	defer srv.Close()
+
+	var responses []interface{}
+	for _, op := range ops {
+		body, err := json.Marshal(map[string]interface{}{
+			"query":     op.query,
+			"variables": op.variables,
+		})
+		if err != nil {
+			return nil, err
+		}
+		resp, err := http.Post(srv.URL, "application/json", bytes.NewReader(body))
+		if err != nil {
+			return nil, err
+		}
+		b, err := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		normalized, err := normalize(b)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", op.name, err)
+		}
+		responses = append(responses, normalized)
+	}
+	return responses, nil
+}
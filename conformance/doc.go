@@ -0,0 +1,14 @@
+// Package conformance checks that the three implementations of the Star Wars
+// API answer alike. Its test starts each server with httptest, sends it
+// every operation of the corpus in order and compares the normalized
+// responses with the golden outputs, reporting every divergence per
+// implementation and operation.
+//
+// An operation is stored in testdata as name.graphql, with its variables, if
+// any, in name.variables.json and its expected response in
+// name.golden.json. Run from the root of the module:
+//
+//	go test ./conformance
+//	go test ./conformance -run TestConformance/gophers/07_starship
+//	go test ./conformance -update   # rewrite the golden outputs from -reference
+package conformance
@@ -0,0 +1,110 @@
+package conformance
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// normalize decodes a GraphQL response into the form compared against the
+// golden outputs. Only the data and the path and code of every error are
+// kept: messages and locations are worded differently by each library.
+func normalize(body []byte) (interface{}, error) {
+	var resp struct {
+		Data   interface{} `json:"data"`
+		Errors []struct {
+			Path       []interface{}          `json:"path"`
+			Extensions map[string]interface{} `json:"extensions"`
+		} `json:"errors"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("invalid response %q: %v", body, err)
+	}
+
+	out := map[string]interface{}{"data": resp.Data}
+	if len(resp.Errors) == 0 {
+		return out, nil
+	}
+	var errs []interface{}
+	for _, e := range resp.Errors {
+		err := map[string]interface{}{}
+		if e.Path != nil {
+			err["path"] = e.Path
+		}
+		if code, ok := e.Extensions["code"]; ok {
+			err["code"] = code
+		}
+		errs = append(errs, err)
+	}
+	// Errors are reported in the order fields happen to resolve.
+	sort.Slice(errs, func(i, j int) bool {
+		return marshal(errs[i]) < marshal(errs[j])
+	})
+	out["errors"] = errs
+	return out, nil
+}
+
+// diff reports every difference between want and got, naming each by its
+// path from the root of the response.
+func diff(path string, want, got interface{}, report func(format string, args ...interface{})) {
+	switch w := want.(type) {
+	case map[string]interface{}:
+		g, ok := got.(map[string]interface{})
+		if !ok {
+			break
+		}
+		keys := map[string]bool{}
+		for k := range w {
+			keys[k] = true
+		}
+		for k := range g {
+			keys[k] = true
+		}
+		var sorted []string
+		for k := range keys {
+			sorted = append(sorted, k)
+		}
+		sort.Strings(sorted)
+		for _, k := range sorted {
+			wv, wok := w[k]
+			gv, gok := g[k]
+			switch {
+			case !gok:
+				report("%s: missing, want %s", join(path, k), marshal(wv))
+			case !wok:
+				report("%s: unexpected %s", join(path, k), marshal(gv))
+			default:
+				diff(join(path, k), wv, gv, report)
+			}
+		}
+		return
+	case []interface{}:
+		g, ok := got.([]interface{})
+		if !ok {
+			break
+		}
+		if len(w) != len(g) {
+			report("%s: want %d elements, got %d", path, len(w), len(g))
+		}
+		for i := 0; i < len(w) && i < len(g); i++ {
+			diff(fmt.Sprintf("%s[%d]", path, i), w[i], g[i], report)
+		}
+		return
+	}
+	if !reflect.DeepEqual(want, got) {
+		report("%s: want %s, got %s", path, marshal(want), marshal(got))
+	}
+}
+
+func join(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+func marshal(v interface{}) string {
+	b, _ := json.Marshal(v)
+	return string(b)
+}
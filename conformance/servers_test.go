@@ -0,0 +1,63 @@
+package conformance
+
+import (
+	"flag"
+	"graphql/apperr"
+	"graphql/dataset"
+	"graphql/gophers-starwar/starwars"
+	"graphql/gqlgen-starwar/generated"
+	"graphql/gqlgen-starwar/resolve"
+	"graphql/graphql-starwar/data"
+	"graphql/graphql-starwar/exec"
+	"io/ioutil"
+	"net/http"
+
+	gqlgen "github.com/99designs/gqlgen/graphql/handler"
+	"github.com/99designs/gqlgen/graphql/handler/transport"
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graphql-go/handler"
+)
+
+var gophersSchema = flag.String("gophers-schema", "../gophers-starwar/schema.graphql", "SDL file of the gophers-starwar server")
+
+// implementation is one of the servers of the Star Wars API.
+type implementation struct {
+	name string
+	// handler returns the GraphQL endpoint of a freshly started server
+	// serving ds.
+	handler func(ds *dataset.Dataset) (http.Handler, error)
+}
+
+var implementations = []implementation{
+	{"gophers", gophersHandler},
+	{"graphql-go", graphqlGoHandler},
+	{"gqlgen", gqlgenHandler},
+}
+
+func gophersHandler(ds *dataset.Dataset) (http.Handler, error) {
+	starwars.Load(ds)
+	sdl, err := ioutil.ReadFile(*gophersSchema)
+	if err != nil {
+		return nil, err
+	}
+	schema, err := graphql.ParseSchema(string(sdl), &starwars.Resolver{})
+	if err != nil {
+		return nil, err
+	}
+	return &starwars.Handler{Schema: schema}, nil
+}
+
+func graphqlGoHandler(ds *dataset.Dataset) (http.Handler, error) {
+	data.Load(ds)
+	return handler.New(&handler.Config{
+		Schema:        &exec.StarWarsSchema,
+		FormatErrorFn: apperr.FormatError,
+	}), nil
+}
+
+func gqlgenHandler(ds *dataset.Dataset) (http.Handler, error) {
+	srv := gqlgen.New(generated.NewExecutableSchema(resolve.NewResolver(ds)))
+	srv.AddTransport(transport.POST{})
+	srv.SetErrorPresenter(apperr.ErrorPresenter)
+	return resolve.LoaderMiddleware(srv), nil
+}
@@ -0,0 +1,205 @@
+// Package dataset loads the canonical Star Wars data served by every
+// implementation of the API: the characters and their friendships, the
+// starships and the reviews posted at startup.
+//
+// The data comes from a JSON or YAML fixture. The fixture embedded in the
+// package is used unless another one is given by path.
+package dataset
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v2"
+)
+
+//go:embed starwars.yaml
+var embedded []byte
+
+// Dataset is the content of a fixture.
+type Dataset struct {
+	Humans    []*Human    `json:"humans" yaml:"humans"`
+	Droids    []*Droid    `json:"droids" yaml:"droids"`
+	Starships []*Starship `json:"starships" yaml:"starships"`
+	Reviews   []*Review   `json:"reviews" yaml:"reviews"`
+}
+
+// Human is a humanoid character. Friends holds the IDs of humans and droids,
+// Starships the IDs of starships.
+type Human struct {
+	ID        string   `json:"id" yaml:"id"`
+	Name      string   `json:"name" yaml:"name"`
+	Friends   []string `json:"friends" yaml:"friends"`
+	AppearsIn []string `json:"appearsIn" yaml:"appearsIn"`
+	// Height is in meters.
+	Height float64 `json:"height" yaml:"height"`
+	// Mass is in kilograms, nil if unknown.
+	Mass      *float64 `json:"mass" yaml:"mass"`
+	Starships []string `json:"starships" yaml:"starships"`
+}
+
+// Droid is a mechanical character. Friends holds the IDs of humans and
+// droids.
+type Droid struct {
+	ID              string   `json:"id" yaml:"id"`
+	Name            string   `json:"name" yaml:"name"`
+	Friends         []string `json:"friends" yaml:"friends"`
+	AppearsIn       []string `json:"appearsIn" yaml:"appearsIn"`
+	PrimaryFunction string   `json:"primaryFunction" yaml:"primaryFunction"`
+}
+
+// Starship is a ship piloted by humans.
+type Starship struct {
+	ID   string `json:"id" yaml:"id"`
+	Name string `json:"name" yaml:"name"`
+	// Length is in meters.
+	Length  float64 `json:"length" yaml:"length"`
+	History [][]int `json:"history" yaml:"history"`
+}
+
+// Review is a review of an episode.
+type Review struct {
+	Episode    string     `json:"episode" yaml:"episode"`
+	Stars      int        `json:"stars" yaml:"stars"`
+	Commentary *string    `json:"commentary" yaml:"commentary"`
+	Time       *time.Time `json:"time" yaml:"time"`
+}
+
+// Episodes are the names of the episodes a fixture may refer to.
+var Episodes = []string{"NEWHOPE", "EMPIRE", "JEDI"}
+
+// Default returns the embedded fixture.
+func Default() *Dataset {
+	ds, err := Parse(embedded, "yaml")
+	if err != nil {
+		panic(fmt.Sprintf("dataset: embedded fixture: %v", err))
+	}
+	return ds
+}
+
+// Open loads the fixture at path, or the embedded one if path is empty. The
+// format is told by the extension of path: .json, .yaml or .yml.
+func Open(path string) (*Dataset, error) {
+	if path == "" {
+		return Default(), nil
+	}
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	ds, err := Parse(b, strings.TrimPrefix(filepath.Ext(path), "."))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+	return ds, nil
+}
+
+// Parse decodes a fixture in the given format, "json" or "yaml", and
+// validates it.
+func Parse(b []byte, format string) (*Dataset, error) {
+	ds := &Dataset{}
+	switch format {
+	case "json":
+		dec := json.NewDecoder(bytes.NewReader(b))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(ds); err != nil {
+			return nil, err
+		}
+	case "yaml", "yml":
+		if err := yaml.UnmarshalStrict(b, ds); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unknown fixture format %q", format)
+	}
+	if err := ds.Validate(); err != nil {
+		return nil, err
+	}
+	return ds, nil
+}
+
+// Validate checks that IDs are unique, that every friend, starship and
+// episode referred to exists, and that every review has a time and between 0
+// and 5 stars, as the reviews created through the servers do.
+func (ds *Dataset) Validate() error {
+	var problems []string
+	fail := func(format string, args ...interface{}) {
+		problems = append(problems, fmt.Sprintf(format, args...))
+	}
+
+	characters := map[string]bool{}
+	starships := map[string]bool{}
+	ids := map[string]bool{}
+	declare := func(kind, id string) {
+		if id == "" {
+			fail("%s without an id", kind)
+			return
+		}
+		if ids[id] {
+			fail("duplicate id %q", id)
+		}
+		ids[id] = true
+	}
+	for _, h := range ds.Humans {
+		declare("human", h.ID)
+		characters[h.ID] = true
+	}
+	for _, d := range ds.Droids {
+		declare("droid", d.ID)
+		characters[d.ID] = true
+	}
+	for _, s := range ds.Starships {
+		declare("starship", s.ID)
+		starships[s.ID] = true
+	}
+
+	episodes := map[string]bool{}
+	for _, e := range Episodes {
+		episodes[e] = true
+	}
+	checkCharacter := func(kind, id string, friends, appearsIn []string) {
+		for _, f := range friends {
+			if !characters[f] {
+				fail("%s %q has unknown friend %q", kind, id, f)
+			}
+		}
+		for _, e := range appearsIn {
+			if !episodes[e] {
+				fail("%s %q appears in unknown episode %q", kind, id, e)
+			}
+		}
+	}
+	for _, h := range ds.Humans {
+		checkCharacter("human", h.ID, h.Friends, h.AppearsIn)
+		for _, s := range h.Starships {
+			if !starships[s] {
+				fail("human %q has unknown starship %q", h.ID, s)
+			}
+		}
+	}
+	for _, d := range ds.Droids {
+		checkCharacter("droid", d.ID, d.Friends, d.AppearsIn)
+	}
+	for i, r := range ds.Reviews {
+		if !episodes[r.Episode] {
+			fail("review %d is of unknown episode %q", i, r.Episode)
+		}
+		if r.Stars < 0 || r.Stars > 5 {
+			fail("review %d has %d stars, not between 0 and 5", i, r.Stars)
+		}
+		if r.Time == nil {
+			fail("review %d has no time", i)
+		}
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("invalid dataset: %s", strings.Join(problems, "; "))
+	}
+	return nil
+}
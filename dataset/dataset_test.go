@@ -0,0 +1,130 @@
+package dataset
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDefault(t *testing.T) {
+	ds := Default()
+	if len(ds.Humans) == 0 || len(ds.Droids) == 0 || len(ds.Starships) == 0 {
+		t.Fatalf("got %d humans, %d droids and %d starships, want some of each", len(ds.Humans), len(ds.Droids), len(ds.Starships))
+	}
+	if ds.Humans[0].ID != "1000" || ds.Humans[0].Name != "Luke Skywalker" {
+		t.Errorf("got first human %+v, want Luke Skywalker", ds.Humans[0])
+	}
+}
+
+func TestOpen(t *testing.T) {
+	want := Default()
+	b, err := json.Marshal(want)
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "starwars.json")
+	if err := os.WriteFile(path, b, 0644); err != nil {
+		t.Fatal(err)
+	}
+	got, err := Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Error("the JSON fixture differs from the YAML one it was written from")
+	}
+
+	if _, err := Open(filepath.Join(t.TempDir(), "starwars.toml")); err == nil {
+		t.Error("opened a fixture that does not exist")
+	}
+	if err := os.WriteFile(path+".txt", b, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Open(path + ".txt"); err == nil || !strings.Contains(err.Error(), "unknown fixture format") {
+		t.Errorf("got error %v for a .txt fixture, want an unknown format", err)
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		format  string
+		fixture string
+		// problems are parts of the error expected, none if valid.
+		problems []string
+	}{
+		{
+			name:   "valid",
+			format: "yaml",
+			fixture: `
+humans: [{id: "1", name: Luke, friends: ["2"], appearsIn: [NEWHOPE], starships: ["3"]}]
+droids: [{id: "2", name: R2-D2, friends: ["1"], appearsIn: [JEDI]}]
+starships: [{id: "3", name: X-wing}]
+reviews: [{episode: JEDI, stars: 5, time: 1983-05-25T00:00:00Z}]
+`,
+		},
+		{
+			name:     "unknown field",
+			format:   "json",
+			fixture:  `{"humans": [{"id": "1", "side": "light"}]}`,
+			problems: []string{"side"},
+		},
+		{
+			name:     "unknown YAML field",
+			format:   "yml",
+			fixture:  `planets: [{id: "1"}]`,
+			problems: []string{"planets"},
+		},
+		{
+			name:   "broken references",
+			format: "yaml",
+			fixture: `
+humans: [{id: "1", friends: ["9"], appearsIn: [PHANTOM], starships: ["8"]}]
+droids: [{id: "1"}, {name: no id}]
+`,
+			problems: []string{
+				`duplicate id "1"`,
+				"droid without an id",
+				`human "1" has unknown friend "9"`,
+				`human "1" appears in unknown episode "PHANTOM"`,
+				`human "1" has unknown starship "8"`,
+			},
+		},
+		{
+			name:   "invalid reviews",
+			format: "yaml",
+			fixture: `
+reviews:
+  - {episode: JEDI, stars: 6, time: 1983-05-25T00:00:00Z}
+  - {episode: CLONES, stars: 1}
+`,
+			problems: []string{
+				"review 0 has 6 stars",
+				`review 1 is of unknown episode "CLONES"`,
+				"review 1 has no time",
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := Parse([]byte(test.fixture), test.format)
+			if len(test.problems) == 0 {
+				if err != nil {
+					t.Errorf("got error %v, want none", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("got no error, want %q", test.problems)
+			}
+			for _, problem := range test.problems {
+				if !strings.Contains(err.Error(), problem) {
+					t.Errorf("got error %q, want it to mention %q", err, problem)
+				}
+			}
+		})
+	}
+}
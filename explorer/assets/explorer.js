@@ -0,0 +1,132 @@
+(function () {
+    var query = document.getElementById("query");
+    var variables = document.getElementById("variables");
+    var headers = document.getElementById("headers");
+    var response = document.getElementById("response");
+    var status = document.getElementById("status");
+
+    var saved = JSON.parse(localStorage.getItem("explorer:" + config.endpoint) || "{}");
+    query.value = saved.query || "{\n  __typename\n}\n";
+    variables.value = saved.variables || "";
+    headers.value = saved.headers || JSON.stringify(config.headers, null, 2);
+
+    function save() {
+        localStorage.setItem("explorer:" + config.endpoint, JSON.stringify({
+            query: query.value,
+            variables: variables.value,
+            headers: headers.value,
+        }));
+    }
+
+    function parse(textarea, name) {
+        if (textarea.value.trim() === "") {
+            return {};
+        }
+        try {
+            return JSON.parse(textarea.value);
+        } catch (error) {
+            throw new Error(name + " are not valid JSON: " + error.message);
+        }
+    }
+
+    function send(body) {
+        var h = Object.assign({"Content-Type": "application/json"}, parse(headers, "Headers"));
+        return fetch(config.endpoint, {
+            method: "post",
+            headers: h,
+            body: JSON.stringify(body),
+            credentials: "include",
+        }).then(function (res) {
+            return res.text();
+        });
+    }
+
+    function run() {
+        save();
+        var body;
+        try {
+            body = {query: query.value, variables: parse(variables, "Variables")};
+        } catch (error) {
+            response.textContent = error.message;
+            return;
+        }
+        var start = Date.now();
+        status.textContent = "Running...";
+        send(body).then(function (text) {
+            status.textContent = (Date.now() - start) + " ms";
+            try {
+                response.textContent = JSON.stringify(JSON.parse(text), null, 2);
+            } catch (error) {
+                response.textContent = text;
+            }
+        }).catch(function (error) {
+            status.textContent = "";
+            response.textContent = error.message;
+        });
+    }
+
+    document.getElementById("run").addEventListener("click", run);
+    document.getElementById("prettify").addEventListener("click", function () {
+        try {
+            variables.value = JSON.stringify(parse(variables, "Variables"), null, 2);
+        } catch (error) {
+            response.textContent = error.message;
+        }
+    });
+    document.addEventListener("keydown", function (e) {
+        if ((e.ctrlKey || e.metaKey) && e.key === "Enter") {
+            e.preventDefault();
+            run();
+        }
+    });
+
+    function typeName(t) {
+        if (t.kind === "NON_NULL") {
+            return typeName(t.ofType) + "!";
+        }
+        if (t.kind === "LIST") {
+            return "[" + typeName(t.ofType) + "]";
+        }
+        return t.name;
+    }
+
+    function element(tag, className, text) {
+        var e = document.createElement(tag);
+        if (className) {
+            e.className = className;
+        }
+        if (text) {
+            e.textContent = text;
+        }
+        return e;
+    }
+
+    var introspection = "{ __schema { types { name kind description " +
+        "fields { name description args { name type { ...ref } } type { ...ref } } } } } " +
+        "fragment ref on __Type { kind name ofType { kind name ofType { kind name ofType { kind name ofType { kind name } } } } }";
+
+    send({query: introspection}).then(function (text) {
+        var types = JSON.parse(text).data.__schema.types;
+        var schema = document.getElementById("schema");
+        schema.textContent = "";
+        types.filter(function (t) {
+            return t.name.indexOf("__") !== 0 && t.fields;
+        }).forEach(function (t) {
+            var div = element("div", "type");
+            div.appendChild(element("b", "", t.name));
+            t.fields.forEach(function (f) {
+                var args = f.args.map(function (a) {
+                    return a.name + ": " + typeName(a.type);
+                }).join(", ");
+                var field = element("div", "field", f.name + (args ? "(" + args + ")" : "") + ": " + typeName(f.type));
+                if (f.description) {
+                    field.appendChild(element("div", "description", f.description));
+                }
+                div.appendChild(field);
+            });
+            schema.appendChild(div);
+        });
+    }).catch(function (error) {
+        document.getElementById("schema").textContent = "Could not load the schema: " + error.message;
+    });
+})();
@@ -0,0 +1,90 @@
+// Package explorer serves an in-browser GraphQL explorer whose assets are
+// embedded in the binary, so it works without access to a CDN.
+//
+// The explorer is GraphiQL when its assets are present in assets/vendor,
+// and otherwise a small built-in page that sends operations and shows the
+// schema. They are not committed, so the built-in page is served unless the
+// go:generate directives below were run to download the pinned versions
+// before building; building never runs them.
+package explorer
+
+//go:generate curl -sSfLo assets/vendor/react.production.min.js https://unpkg.com/react@16.14.0/umd/react.production.min.js
+//go:generate curl -sSfLo assets/vendor/react-dom.production.min.js https://unpkg.com/react-dom@16.14.0/umd/react-dom.production.min.js
+//go:generate curl -sSfLo assets/vendor/graphiql.min.js https://unpkg.com/graphiql@1.4.7/graphiql.min.js
+//go:generate curl -sSfLo assets/vendor/graphiql.min.css https://unpkg.com/graphiql@1.4.7/graphiql.min.css
+
+import (
+	"embed"
+	"encoding/json"
+	"html/template"
+	"io/fs"
+	"net/http"
+	"path"
+)
+
+//go:embed assets
+var assets embed.FS
+
+var (
+	builtinPage  = template.Must(template.ParseFS(assets, "assets/index.html"))
+	graphiqlPage = template.Must(template.ParseFS(assets, "assets/graphiql.html"))
+)
+
+// Config describes the explorer page.
+type Config struct {
+	// Title is the title of the page.
+	Title string
+	// Endpoint is the URL of the GraphQL endpoint, absolute or relative to
+	// the page.
+	Endpoint string
+	// Headers are sent with every operation, and can be edited in the page.
+	Headers map[string]string
+}
+
+// Handler serves the explorer page at the root of its path and the assets
+// below it. Mount it with http.StripPrefix to serve it below a prefix.
+func Handler(c Config) http.Handler {
+	static, err := fs.Sub(assets, "assets")
+	if err != nil {
+		panic(err)
+	}
+	files := http.FileServer(http.FS(static))
+
+	page := builtinPage
+	if vendored() {
+		page = graphiqlPage
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if path.Clean("/"+r.URL.Path) != "/" {
+			files.ServeHTTP(w, r)
+			return
+		}
+		headers, err := json.Marshal(c.Headers)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		page.Execute(w, map[string]interface{}{
+			"Title":    c.Title,
+			"Endpoint": c.Endpoint,
+			"Headers":  template.JS(headers),
+		})
+	})
+}
+
+// vendored tells whether the GraphiQL assets have been vendored.
+func vendored() bool {
+	for _, name := range []string{
+		"react.production.min.js",
+		"react-dom.production.min.js",
+		"graphiql.min.js",
+		"graphiql.min.css",
+	} {
+		if _, err := fs.Stat(assets, "assets/vendor/"+name); err != nil {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,44 @@
+package explorer
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+)
+
+// Flags registers the command line flags that configure the explorer,
+// starting from the given title and endpoint, and returns the config they
+// fill in.
+func Flags(title, endpoint string) *Config {
+	c := &Config{Title: title, Endpoint: endpoint}
+	flag.StringVar(&c.Endpoint, "explorer-endpoint", c.Endpoint, "URL of the GraphQL endpoint used by the explorer")
+	flag.Var(headers{c}, "explorer-header", "header sent by the explorer as Name: value, may be repeated")
+	return c
+}
+
+type headers struct {
+	config *Config
+}
+
+func (h headers) String() string {
+	if h.config == nil {
+		return ""
+	}
+	var l []string
+	for name, value := range h.config.Headers {
+		l = append(l, name+": "+value)
+	}
+	return strings.Join(l, ", ")
+}
+
+func (h headers) Set(s string) error {
+	parts := strings.SplitN(s, ":", 2)
+	if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" {
+		return fmt.Errorf("header %q is not in the form Name: value", s)
+	}
+	if h.config.Headers == nil {
+		h.config.Headers = map[string]string{}
+	}
+	h.config.Headers[strings.TrimSpace(parts[0])] = strings.TrimSpace(parts[1])
+	return nil
+}
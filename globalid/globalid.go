@@ -0,0 +1,68 @@
+// Package globalid implements the opaque global object identifiers used by
+// the Relay Node interface. A global ID encodes the name of the object type
+// together with the raw key of the object within that type.
+package globalid
+
+import (
+	"encoding/base64"
+	"graphql/apperr"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// Encode returns the global ID of the object of the given type and key.
+func Encode(typeName, key string) string {
+	return base64.StdEncoding.EncodeToString([]byte(typeName + ":" + key))
+}
+
+// Decode splits a global ID into the type name and key it was built from.
+func Decode(id string) (typeName, key string, err error) {
+	b, err := base64.StdEncoding.DecodeString(id)
+	if err != nil {
+		return "", "", apperr.BadUserInputf("invalid global id %q", id)
+	}
+	parts := strings.SplitN(string(b), ":", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", apperr.BadUserInputf("invalid global id %q", id)
+	}
+	return parts[0], parts[1], nil
+}
+
+// Key returns the raw key of id if it is a global ID of one of the given
+// types, and id unchanged if it is a raw key or a global ID of another type,
+// so lookups accept both forms. An id that decodes to a type name and a
+// separator but is not a valid global ID is an error rather than a raw key.
+func Key(id string, typeNames ...string) (string, error) {
+	typeName, key, err := Decode(id)
+	if err != nil {
+		if looksGlobal(id) {
+			return "", err
+		}
+		return id, nil
+	}
+	for _, t := range typeNames {
+		if t == typeName {
+			return key, nil
+		}
+	}
+	return id, nil
+}
+
+// looksGlobal reports whether id is meant as a global ID: base64, padded or
+// not, of text starting with a type name followed by the separator. Raw keys
+// such as 1000 never decode to that.
+func looksGlobal(id string) bool {
+	for _, enc := range []*base64.Encoding{base64.StdEncoding, base64.RawStdEncoding} {
+		b, err := enc.DecodeString(id)
+		if err != nil || !utf8.Valid(b) {
+			continue
+		}
+		if i := strings.IndexByte(string(b), ':'); i >= 0 && typeNamePattern.MatchString(string(b[:i])) {
+			return true
+		}
+	}
+	return false
+}
+
+var typeNamePattern = regexp.MustCompile(`^[_A-Za-z][_0-9A-Za-z]*$`)
@@ -1,6 +1,6 @@
 module graphql
 
-go 1.14
+go 1.16
 
 require (
 	github.com/99designs/gqlgen v0.13.0
@@ -9,4 +9,5 @@ require (
 	github.com/graphql-go/graphql v0.7.9
 	github.com/graphql-go/handler v0.2.3
 	github.com/vektah/gqlparser/v2 v2.1.0
+	gopkg.in/yaml.v2 v2.2.4
 )
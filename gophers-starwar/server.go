@@ -1,20 +1,47 @@
 package main
 
 import (
+	"flag"
+	"graphql/dataset"
+	"graphql/explorer"
+	"graphql/gophers-starwar/starwars"
+	"graphql/metrics"
+	"graphql/querylimit"
 	"io/ioutil"
 	"log"
 	"net/http"
 	"os"
 
 	"github.com/graph-gophers/graphql-go"
-	"github.com/graph-gophers/graphql-go/relay"
+	"github.com/vektah/gqlparser/v2"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+var (
+	datasetPath = flag.String("dataset", "", "JSON or YAML fixture of the Star Wars data, the embedded one if empty")
+	limits      = querylimit.Flags()
+	explore     = explorer.Flags("Star Wars", "/query")
 )
 
 func main() {
+	flag.Parse()
+
+	ds, err := dataset.Open(*datasetPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+	starwars.Load(ds)
 
-	schema := graphql.MustParseSchema(readSchema(), &Resolver{})
-	http.Handle("/", http.FileServer(http.Dir("./graphqlgo-starwar/index")))
-	http.Handle("/query", &relay.Handler{Schema: schema})
+	sdl := readSchema()
+	m := metrics.New()
+	schema := graphql.MustParseSchema(sdl, &starwars.Resolver{}, graphql.Tracer(metrics.Tracer{Metrics: m}))
+	limitSchema, gqlErr := gqlparser.LoadSchema(&ast.Source{Name: "schema.graphql", Input: sdl})
+	if gqlErr != nil {
+		log.Fatal(gqlErr)
+	}
+	http.Handle("/", explorer.Handler(*explore))
+	http.Handle("/query", m.Middleware(&starwars.Handler{Schema: schema, Check: limits.Checker(querylimit.ASTSchema(limitSchema))}))
+	http.Handle("/metrics", m)
 
 	log.Fatal(http.ListenAndServe(":8080", nil))
 }
@@ -0,0 +1,51 @@
+package starwars
+
+import (
+	"encoding/json"
+	"graphql/apperr"
+	"net/http"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/errors"
+)
+
+// Handler serves GraphQL requests like relay.Handler, but presents the errors
+// of resolvers through apperr so internal failures are not leaked.
+type Handler struct {
+	Schema *graphql.Schema
+	// Check, if set, is called with the operation about to be executed, and
+	// the error it returns, presented through apperr, is the response
+	// instead.
+	Check func(query, operationName string, variables map[string]interface{}) error
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var params struct {
+		Query         string                 `json:"query"`
+		OperationName string                 `json:"operationName"`
+		Variables     map[string]interface{} `json:"variables"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var response *graphql.Response
+	if h.Check != nil {
+		if err := h.Check(params.Query, params.OperationName, params.Variables); err != nil {
+			response = &graphql.Response{Errors: []*errors.QueryError{{Message: err.Error(), ResolverError: err}}}
+		}
+	}
+	if response == nil {
+		response = h.Schema.Exec(r.Context(), params.Query, params.OperationName, params.Variables)
+	}
+	apperr.PresentQueryErrors(response.Errors)
+	responseJSON, err := json.Marshal(response)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(responseJSON)
+}
@@ -0,0 +1,590 @@
+// Package starwars resolves the Star Wars schema with graph-gophers/graphql-go.
+package starwars
+
+import (
+	"graphql/apperr"
+	"graphql/dataset"
+	"graphql/globalid"
+	"graphql/pagination"
+	"strings"
+	"time"
+
+	graphql "github.com/graph-gophers/graphql-go"
+)
+
+type human struct {
+	ID        graphql.ID
+	Name      string
+	Friends   []graphql.ID
+	AppearsIn []string
+	Height    float64
+	Mass      *float64
+	Starships []graphql.ID
+}
+
+type droid struct {
+	ID              graphql.ID
+	Name            string
+	Friends         []graphql.ID
+	AppearsIn       []string
+	PrimaryFunction string
+}
+
+type starship struct {
+	ID      graphql.ID
+	Name    string
+	Length  float64
+	History [][]int32
+}
+
+type review struct {
+	stars      int32
+	commentary *string
+	time       *graphql.Time
+}
+
+var (
+	humans       []*human
+	humanData    map[graphql.ID]*human
+	droids       []*droid
+	droidData    map[graphql.ID]*droid
+	starships    []*starship
+	starshipData map[graphql.ID]*starship
+	reviews      map[string][]*review
+)
+
+func init() {
+	Load(dataset.Default())
+}
+
+// Load replaces the characters, starships and reviews with those of ds.
+func Load(ds *dataset.Dataset) {
+	humans = nil
+	humanData = make(map[graphql.ID]*human)
+	for _, h := range ds.Humans {
+		human := &human{
+			ID:        graphql.ID(h.ID),
+			Name:      h.Name,
+			Friends:   ids(h.Friends),
+			AppearsIn: h.AppearsIn,
+			Height:    h.Height,
+			Mass:      h.Mass,
+			Starships: ids(h.Starships),
+		}
+		humans = append(humans, human)
+		humanData[human.ID] = human
+	}
+
+	droids = nil
+	droidData = make(map[graphql.ID]*droid)
+	for _, d := range ds.Droids {
+		droid := &droid{
+			ID:              graphql.ID(d.ID),
+			Name:            d.Name,
+			Friends:         ids(d.Friends),
+			AppearsIn:       d.AppearsIn,
+			PrimaryFunction: d.PrimaryFunction,
+		}
+		droids = append(droids, droid)
+		droidData[droid.ID] = droid
+	}
+
+	starships = nil
+	starshipData = make(map[graphql.ID]*starship)
+	for _, s := range ds.Starships {
+		starship := &starship{
+			ID:     graphql.ID(s.ID),
+			Name:   s.Name,
+			Length: s.Length,
+		}
+		for _, h := range s.History {
+			point := make([]int32, len(h))
+			for i, v := range h {
+				point[i] = int32(v)
+			}
+			starship.History = append(starship.History, point)
+		}
+		starships = append(starships, starship)
+		starshipData[starship.ID] = starship
+	}
+
+	reviews = make(map[string][]*review)
+	for _, r := range ds.Reviews {
+		rev := &review{
+			stars:      int32(r.Stars),
+			commentary: r.Commentary,
+		}
+		if r.Time != nil {
+			rev.time = &graphql.Time{Time: *r.Time}
+		}
+		reviews[r.Episode] = append(reviews[r.Episode], rev)
+	}
+}
+
+func ids(l []string) []graphql.ID {
+	res := make([]graphql.ID, len(l))
+	for i, id := range l {
+		res[i] = graphql.ID(id)
+	}
+	return res
+}
+
+type Resolver struct{}
+
+func (r *Resolver) Hero(args struct{ Episode string }) *characterResolver {
+	if args.Episode == "EMPIRE" {
+		return &characterResolver{&humanResolver{humanData["1000"]}}
+	}
+	return &characterResolver{&droidResolver{droidData["2001"]}}
+}
+
+func (r *Resolver) Reviews(args struct {
+	Episode string
+	Since   *graphql.Time
+}) []*reviewResolver {
+	var l []*reviewResolver
+	for _, review := range reviews[args.Episode] {
+		if args.Since != nil && (review.time == nil || !review.time.After(args.Since.Time)) {
+			continue
+		}
+		l = append(l, &reviewResolver{review})
+	}
+	return l
+}
+
+func (r *Resolver) Search(args struct{ Text string }) []*searchResultResolver {
+	var l []*searchResultResolver
+	for _, h := range humans {
+		if strings.Contains(h.Name, args.Text) {
+			l = append(l, &searchResultResolver{&humanResolver{h}})
+		}
+	}
+	for _, d := range droids {
+		if strings.Contains(d.Name, args.Text) {
+			l = append(l, &searchResultResolver{&droidResolver{d}})
+		}
+	}
+	for _, s := range starships {
+		if strings.Contains(s.Name, args.Text) {
+			l = append(l, &searchResultResolver{&starshipResolver{s}})
+		}
+	}
+	return l
+}
+
+func (r *Resolver) Character(args struct{ ID graphql.ID }) (*characterResolver, error) {
+	key, err := globalid.Key(string(args.ID), "Human", "Droid")
+	if err != nil {
+		return nil, err
+	}
+	if c := resolveCharacter(graphql.ID(key)); c != nil {
+		return c, nil
+	}
+	return nil, apperr.NotFoundf("character %q not found", args.ID)
+}
+
+func (r *Resolver) Human(args struct{ ID graphql.ID }) (*humanResolver, error) {
+	key, err := globalid.Key(string(args.ID), "Human")
+	if err != nil {
+		return nil, err
+	}
+	if h := humanData[graphql.ID(key)]; h != nil {
+		return &humanResolver{h}, nil
+	}
+	return nil, apperr.NotFoundf("human %q not found", args.ID)
+}
+
+func (r *Resolver) Droid(args struct{ ID graphql.ID }) (*droidResolver, error) {
+	key, err := globalid.Key(string(args.ID), "Droid")
+	if err != nil {
+		return nil, err
+	}
+	if d := droidData[graphql.ID(key)]; d != nil {
+		return &droidResolver{d}, nil
+	}
+	return nil, apperr.NotFoundf("droid %q not found", args.ID)
+}
+
+func (r *Resolver) Starship(args struct{ ID graphql.ID }) (*starshipResolver, error) {
+	key, err := globalid.Key(string(args.ID), "Starship")
+	if err != nil {
+		return nil, err
+	}
+	if s := starshipData[graphql.ID(key)]; s != nil {
+		return &starshipResolver{s}, nil
+	}
+	return nil, apperr.NotFoundf("starship %q not found", args.ID)
+}
+
+func (r *Resolver) Node(args struct{ ID graphql.ID }) (*nodeResolver, error) {
+	return resolveNode(args.ID)
+}
+
+func (r *Resolver) Nodes(args struct{ IDs []graphql.ID }) ([]*nodeResolver, error) {
+	l := make([]*nodeResolver, len(args.IDs))
+	for i, id := range args.IDs {
+		n, err := resolveNode(id)
+		if err != nil {
+			return nil, err
+		}
+		l[i] = n
+	}
+	return l, nil
+}
+
+func (r *Resolver) CreateReview(args *struct {
+	Episode string
+	Review  *reviewInput
+}) *reviewResolver {
+	review := &review{
+		stars:      args.Review.Stars,
+		commentary: args.Review.Commentary,
+		time:       args.Review.Time,
+	}
+	if review.time == nil {
+		review.time = &graphql.Time{Time: time.Now()}
+	}
+	reviews[args.Episode] = append(reviews[args.Episode], review)
+	return &reviewResolver{review}
+}
+
+type friendsConnectionArgs struct {
+	First  *int32
+	After  *graphql.ID
+	Last   *int32
+	Before *graphql.ID
+}
+
+type character interface {
+	ID() graphql.ID
+	Name() string
+	Friends() *[]*characterResolver
+	FriendsConnection(friendsConnectionArgs) (*friendsConnectionResolver, error)
+	AppearsIn() []string
+}
+
+type characterResolver struct {
+	character
+}
+
+func (r *characterResolver) ToHuman() (*humanResolver, bool) {
+	c, ok := r.character.(*humanResolver)
+	return c, ok
+}
+
+func (r *characterResolver) ToDroid() (*droidResolver, bool) {
+	c, ok := r.character.(*droidResolver)
+	return c, ok
+}
+
+type humanResolver struct {
+	h *human
+}
+
+func (r *humanResolver) ID() graphql.ID {
+	return graphql.ID(globalid.Encode("Human", string(r.h.ID)))
+}
+
+func (r *humanResolver) Name() string {
+	return r.h.Name
+}
+
+func (r *humanResolver) Height(args struct{ Unit string }) float64 {
+	return convertLength(r.h.Height, args.Unit)
+}
+
+func (r *humanResolver) Mass() *float64 {
+	return r.h.Mass
+}
+
+func (r *humanResolver) Friends() *[]*characterResolver {
+	return resolveCharacters(r.h.Friends)
+}
+
+func (r *humanResolver) FriendsConnection(args friendsConnectionArgs) (*friendsConnectionResolver, error) {
+	return newFriendsConnectionResolver(r.h.Friends, args)
+}
+
+func (r *humanResolver) AppearsIn() []string {
+	return r.h.AppearsIn
+}
+
+func (r *humanResolver) Starships() *[]*starshipResolver {
+	l := make([]*starshipResolver, len(r.h.Starships))
+	for i, id := range r.h.Starships {
+		l[i] = &starshipResolver{starshipData[id]}
+	}
+	return &l
+}
+
+type droidResolver struct {
+	d *droid
+}
+
+func (r *droidResolver) ID() graphql.ID {
+	return graphql.ID(globalid.Encode("Droid", string(r.d.ID)))
+}
+
+func (r *droidResolver) Name() string {
+	return r.d.Name
+}
+
+func (r *droidResolver) Friends() *[]*characterResolver {
+	return resolveCharacters(r.d.Friends)
+}
+
+func (r *droidResolver) FriendsConnection(args friendsConnectionArgs) (*friendsConnectionResolver, error) {
+	return newFriendsConnectionResolver(r.d.Friends, args)
+}
+
+func (r *droidResolver) AppearsIn() []string {
+	return r.d.AppearsIn
+}
+
+func (r *droidResolver) PrimaryFunction() *string {
+	if r.d.PrimaryFunction == "" {
+		return nil
+	}
+	return &r.d.PrimaryFunction
+}
+
+type starshipResolver struct {
+	s *starship
+}
+
+func (r *starshipResolver) ID() graphql.ID {
+	return graphql.ID(globalid.Encode("Starship", string(r.s.ID)))
+}
+
+func (r *starshipResolver) Name() string {
+	return r.s.Name
+}
+
+func (r *starshipResolver) Length(args struct{ Unit string }) float64 {
+	return convertLength(r.s.Length, args.Unit)
+}
+
+func (r *starshipResolver) History() [][]int32 {
+	return r.s.History
+}
+
+type searchResultResolver struct {
+	result interface{}
+}
+
+func (r *searchResultResolver) ToHuman() (*humanResolver, bool) {
+	res, ok := r.result.(*humanResolver)
+	return res, ok
+}
+
+func (r *searchResultResolver) ToDroid() (*droidResolver, bool) {
+	res, ok := r.result.(*droidResolver)
+	return res, ok
+}
+
+func (r *searchResultResolver) ToStarship() (*starshipResolver, bool) {
+	res, ok := r.result.(*starshipResolver)
+	return res, ok
+}
+
+type node interface {
+	ID() graphql.ID
+}
+
+type nodeResolver struct {
+	node
+}
+
+func (r *nodeResolver) ToHuman() (*humanResolver, bool) {
+	n, ok := r.node.(*humanResolver)
+	return n, ok
+}
+
+func (r *nodeResolver) ToDroid() (*droidResolver, bool) {
+	n, ok := r.node.(*droidResolver)
+	return n, ok
+}
+
+func (r *nodeResolver) ToStarship() (*starshipResolver, bool) {
+	n, ok := r.node.(*starshipResolver)
+	return n, ok
+}
+
+func resolveNode(id graphql.ID) (*nodeResolver, error) {
+	typeName, key, err := globalid.Decode(string(id))
+	if err != nil {
+		return nil, err
+	}
+	switch typeName {
+	case "Human":
+		if h := humanData[graphql.ID(key)]; h != nil {
+			return &nodeResolver{&humanResolver{h}}, nil
+		}
+	case "Droid":
+		if d := droidData[graphql.ID(key)]; d != nil {
+			return &nodeResolver{&droidResolver{d}}, nil
+		}
+	case "Starship":
+		if s := starshipData[graphql.ID(key)]; s != nil {
+			return &nodeResolver{&starshipResolver{s}}, nil
+		}
+	default:
+		return nil, apperr.BadUserInputf("unknown type %q in global id %q", typeName, id)
+	}
+	return nil, nil
+}
+
+func convertLength(meters float64, unit string) float64 {
+	switch unit {
+	case "METER":
+		return meters
+	case "FOOT":
+		return meters * 3.28084
+	default:
+		panic("invalid unit")
+	}
+}
+
+func resolveCharacters(ids []graphql.ID) *[]*characterResolver {
+	var characters []*characterResolver
+	for _, id := range ids {
+		if c := resolveCharacter(id); c != nil {
+			characters = append(characters, c)
+		}
+	}
+	return &characters
+}
+
+func resolveCharacter(id graphql.ID) *characterResolver {
+	if h, ok := humanData[id]; ok {
+		return &characterResolver{&humanResolver{h}}
+	}
+	if d, ok := droidData[id]; ok {
+		return &characterResolver{&droidResolver{d}}
+	}
+	return nil
+}
+
+type reviewResolver struct {
+	r *review
+}
+
+func (r *reviewResolver) Stars() int32 {
+	return r.r.stars
+}
+
+func (r *reviewResolver) Commentary() *string {
+	return r.r.commentary
+}
+
+func (r *reviewResolver) Time() *graphql.Time {
+	return r.r.time
+}
+
+type friendsConnectionResolver struct {
+	ids    []graphql.ID
+	window pagination.Window
+}
+
+func newFriendsConnectionResolver(ids []graphql.ID, args friendsConnectionArgs) (*friendsConnectionResolver, error) {
+	var page pagination.Args
+	if args.First != nil {
+		first := int(*args.First)
+		page.First = &first
+	}
+	if args.Last != nil {
+		last := int(*args.Last)
+		page.Last = &last
+	}
+	if args.After != nil {
+		after := string(*args.After)
+		page.After = &after
+	}
+	if args.Before != nil {
+		before := string(*args.Before)
+		page.Before = &before
+	}
+
+	window, err := pagination.Slice(len(ids), page)
+	if err != nil {
+		return nil, err
+	}
+	return &friendsConnectionResolver{
+		ids:    ids,
+		window: window,
+	}, nil
+}
+
+func (r *friendsConnectionResolver) TotalCount() int32 {
+	return int32(len(r.ids))
+}
+
+func (r *friendsConnectionResolver) Edges() *[]*friendsEdgeResolver {
+	l := make([]*friendsEdgeResolver, r.window.To-r.window.From)
+	for i := range l {
+		l[i] = &friendsEdgeResolver{
+			cursor: graphql.ID(pagination.EncodeCursor(r.window.From + i)),
+			id:     r.ids[r.window.From+i],
+		}
+	}
+	return &l
+}
+
+func (r *friendsConnectionResolver) Friends() *[]*characterResolver {
+	return resolveCharacters(r.ids[r.window.From:r.window.To])
+}
+
+func (r *friendsConnectionResolver) PageInfo() *pageInfoResolver {
+	p := &pageInfoResolver{
+		hasNextPage:     r.window.HasNextPage,
+		hasPreviousPage: r.window.HasPreviousPage,
+	}
+	if r.window.To > r.window.From {
+		start := graphql.ID(pagination.EncodeCursor(r.window.From))
+		end := graphql.ID(pagination.EncodeCursor(r.window.To - 1))
+		p.startCursor, p.endCursor = &start, &end
+	}
+	return p
+}
+
+type friendsEdgeResolver struct {
+	cursor graphql.ID
+	id     graphql.ID
+}
+
+func (r *friendsEdgeResolver) Cursor() graphql.ID {
+	return r.cursor
+}
+
+func (r *friendsEdgeResolver) Node() *characterResolver {
+	return resolveCharacter(r.id)
+}
+
+type pageInfoResolver struct {
+	startCursor     *graphql.ID
+	endCursor       *graphql.ID
+	hasNextPage     bool
+	hasPreviousPage bool
+}
+
+func (r *pageInfoResolver) StartCursor() *graphql.ID {
+	return r.startCursor
+}
+
+func (r *pageInfoResolver) EndCursor() *graphql.ID {
+	return r.endCursor
+}
+
+func (r *pageInfoResolver) HasNextPage() bool {
+	return r.hasNextPage
+}
+
+func (r *pageInfoResolver) HasPreviousPage() bool {
+	return r.hasPreviousPage
+}
+
+type reviewInput struct {
+	Stars      int32
+	Commentary *string
+	Time       *graphql.Time
+}
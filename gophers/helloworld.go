@@ -1,6 +1,7 @@
 package main
 
 import (
+	"graphql/metrics"
 	"log"
 	"net/http"
 
@@ -19,7 +20,9 @@ func main() {
                         hello: String!
                 }
         `
-	schema := graphql.MustParseSchema(s, &query{})
-	http.Handle("/query", &relay.Handler{Schema: schema})
+	m := metrics.New()
+	schema := graphql.MustParseSchema(s, &query{}, graphql.Tracer(metrics.Tracer{Metrics: m}))
+	http.Handle("/query", m.Middleware(&relay.Handler{Schema: schema}))
+	http.Handle("/metrics", m)
 	log.Fatal(http.ListenAndServe(":8080", nil))
 }
@@ -0,0 +1,129 @@
+// Package gqlclient sends GraphQL operations over HTTP. It is the runtime of
+// the typed clients generated by clientgen, which call Do with the text of
+// each operation and a struct to decode its data into.
+package gqlclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+)
+
+// Client sends operations to a GraphQL endpoint.
+type Client struct {
+	// Endpoint is the URL operations are posted to.
+	Endpoint string
+	// HTTPClient sends the requests, http.DefaultClient if nil.
+	HTTPClient *http.Client
+	// Header is added to every request.
+	Header http.Header
+}
+
+// New returns a client of the endpoint.
+func New(endpoint string) *Client {
+	return &Client{Endpoint: endpoint}
+}
+
+// Error is an error returned by the server in the errors of a response.
+type Error struct {
+	Message    string                 `json:"message"`
+	Path       []interface{}          `json:"path,omitempty"`
+	Extensions map[string]interface{} `json:"extensions,omitempty"`
+}
+
+func (e *Error) Error() string {
+	if len(e.Path) == 0 {
+		return e.Message
+	}
+	var path []string
+	for _, p := range e.Path {
+		path = append(path, fmt.Sprint(p))
+	}
+	return strings.Join(path, ".") + ": " + e.Message
+}
+
+// Code returns the code of the error in its extensions, such as NOT_FOUND,
+// or "" if it has none.
+func (e *Error) Code() string {
+	code, _ := e.Extensions["code"].(string)
+	return code
+}
+
+// Errors are the errors of a response.
+type Errors []*Error
+
+func (l Errors) Error() string {
+	var messages []string
+	for _, e := range l {
+		messages = append(messages, e.Error())
+	}
+	return strings.Join(messages, "; ")
+}
+
+type request struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName,omitempty"`
+	Variables     map[string]interface{} `json:"variables,omitempty"`
+}
+
+type response struct {
+	Data   json.RawMessage `json:"data"`
+	Errors Errors          `json:"errors"`
+}
+
+// Do sends the operation named operationName in query with variables, and
+// decodes the data of the response into data. When the response has
+// errors, the data it has is decoded and Do returns them as Errors.
+func (c *Client) Do(ctx context.Context, operationName, query string, variables map[string]interface{}, data interface{}) error {
+	body, err := json.Marshal(request{query, operationName, variables})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	for name, values := range c.Header {
+		req.Header[name] = values
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	httpClient := c.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	var r response
+	if err := json.Unmarshal(b, &r); err != nil {
+		if resp.StatusCode != http.StatusOK {
+			return fmt.Errorf("%s: %s", resp.Status, bytes.TrimSpace(b))
+		}
+		return fmt.Errorf("decoding response: %v", err)
+	}
+	if len(r.Data) > 0 && string(r.Data) != "null" {
+		if err := json.Unmarshal(r.Data, data); err != nil {
+			return fmt.Errorf("decoding data: %v", err)
+		}
+	}
+	if len(r.Errors) > 0 {
+		return r.Errors
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s", resp.Status)
+	}
+	return nil
+}
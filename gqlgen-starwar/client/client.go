@@ -0,0 +1,406 @@
+// Code generated by clientgen. DO NOT EDIT.
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"graphql/gqlclient"
+	"time"
+)
+
+const friendsQuery = `query Friends ($id: ID!, $first: Int, $after: ID) {
+	character(id: $id) {
+		__typename
+		name
+		friendsConnection(first: $first, after: $after) {
+			totalCount
+			edges {
+				cursor
+				node {
+					__typename
+					name
+				}
+			}
+			pageInfo {
+				hasNextPage
+				endCursor
+			}
+		}
+	}
+}
+`
+
+// Friends sends the Friends query.
+func Friends(ctx context.Context, client *gqlclient.Client, id string, first *int, after *string) (*FriendsResponse, error) {
+	variables := map[string]interface{}{
+		"id": id,
+	}
+	if first != nil {
+		variables["first"] = first
+	}
+	if after != nil {
+		variables["after"] = after
+	}
+	var data FriendsResponse
+	err := client.Do(ctx, "Friends", friendsQuery, variables, &data)
+	return &data, err
+}
+
+const heroQuery = `query Hero ($episode: Episode) {
+	hero(episode: $episode) {
+		__typename
+		... CharacterName
+		appearsIn
+		... HumanDetails
+		... on Droid {
+			primaryFunction
+		}
+		friends {
+			__typename
+			name
+		}
+	}
+}
+fragment CharacterName on Character {
+	id
+	name
+}
+fragment HumanDetails on Human {
+	height(unit: FOOT)
+	mass
+	starships {
+		name
+	}
+}
+`
+
+// Hero sends the Hero query.
+func Hero(ctx context.Context, client *gqlclient.Client, episode *Episode) (*HeroResponse, error) {
+	variables := map[string]interface{}{}
+	if episode != nil {
+		variables["episode"] = episode
+	}
+	var data HeroResponse
+	err := client.Do(ctx, "Hero", heroQuery, variables, &data)
+	return &data, err
+}
+
+const reviewsQuery = `query Reviews ($episode: Episode!, $since: Time) {
+	reviews(episode: $episode, since: $since) {
+		id
+		stars
+		commentary
+		time
+	}
+}
+`
+
+// Reviews sends the Reviews query.
+func Reviews(ctx context.Context, client *gqlclient.Client, episode Episode, since *time.Time) (*ReviewsResponse, error) {
+	variables := map[string]interface{}{
+		"episode": episode,
+	}
+	if since != nil {
+		variables["since"] = since
+	}
+	var data ReviewsResponse
+	err := client.Do(ctx, "Reviews", reviewsQuery, variables, &data)
+	return &data, err
+}
+
+const reviewsPageQuery = `query ReviewsPage ($episode: Episode!, $first: Int, $orderBy: ReviewOrder) {
+	reviewsConnection(episode: $episode, first: $first, orderBy: $orderBy) {
+		totalCount
+		averageStars
+		edges {
+			node {
+				stars
+			}
+		}
+	}
+}
+`
+
+// ReviewsPage sends the ReviewsPage query.
+func ReviewsPage(ctx context.Context, client *gqlclient.Client, episode Episode, first *int, orderBy *ReviewOrder) (*ReviewsPageResponse, error) {
+	variables := map[string]interface{}{
+		"episode": episode,
+	}
+	if first != nil {
+		variables["first"] = first
+	}
+	if orderBy != nil {
+		variables["orderBy"] = orderBy
+	}
+	var data ReviewsPageResponse
+	err := client.Do(ctx, "ReviewsPage", reviewsPageQuery, variables, &data)
+	return &data, err
+}
+
+const createReviewQuery = `mutation CreateReview ($episode: Episode!, $review: ReviewInput!) {
+	createReview(episode: $episode, review: $review) {
+		id
+		stars
+		commentary
+		time
+	}
+}
+`
+
+// CreateReview sends the CreateReview mutation.
+func CreateReview(ctx context.Context, client *gqlclient.Client, episode Episode, review ReviewInput) (*CreateReviewResponse, error) {
+	variables := map[string]interface{}{
+		"episode": episode,
+		"review":  review,
+	}
+	var data CreateReviewResponse
+	err := client.Do(ctx, "CreateReview", createReviewQuery, variables, &data)
+	return &data, err
+}
+
+const searchQuery = `query Search ($text: String!) {
+	search(text: $text) {
+		__typename
+		... on Character {
+			name
+			appearsIn
+		}
+		... on Human {
+			height
+		}
+		... on Starship {
+			name
+			length
+		}
+	}
+}
+`
+
+// Search sends the Search query.
+func Search(ctx context.Context, client *gqlclient.Client, text string) (*SearchResponse, error) {
+	variables := map[string]interface{}{
+		"text": text,
+	}
+	var data SearchResponse
+	err := client.Do(ctx, "Search", searchQuery, variables, &data)
+	return &data, err
+}
+
+type FriendsResponse struct {
+	Character *FriendsCharacter `json:"character"`
+}
+
+type FriendsCharacter struct {
+	Typename          string                            `json:"__typename"`
+	Name              string                            `json:"name"`
+	FriendsConnection FriendsCharacterFriendsConnection `json:"friendsConnection"`
+}
+
+type FriendsCharacterFriendsConnection struct {
+	TotalCount int                                       `json:"totalCount"`
+	Edges      []FriendsCharacterFriendsConnectionEdges  `json:"edges"`
+	PageInfo   FriendsCharacterFriendsConnectionPageInfo `json:"pageInfo"`
+}
+
+type FriendsCharacterFriendsConnectionEdges struct {
+	Cursor string                                      `json:"cursor"`
+	Node   *FriendsCharacterFriendsConnectionEdgesNode `json:"node"`
+}
+
+type FriendsCharacterFriendsConnectionEdgesNode struct {
+	Typename string `json:"__typename"`
+	Name     string `json:"name"`
+}
+
+type FriendsCharacterFriendsConnectionPageInfo struct {
+	HasNextPage bool    `json:"hasNextPage"`
+	EndCursor   *string `json:"endCursor"`
+}
+
+type HeroResponse struct {
+	Hero *HeroHero `json:"hero"`
+}
+
+type HeroHero struct {
+	Typename  string            `json:"__typename"`
+	ID        string            `json:"id"`
+	Name      string            `json:"name"`
+	AppearsIn []Episode         `json:"appearsIn"`
+	Friends   []HeroHeroFriends `json:"friends"`
+	OnHuman   *HeroHeroOnHuman  `json:"-"`
+	OnDroid   *HeroHeroOnDroid  `json:"-"`
+}
+
+func (x *HeroHero) UnmarshalJSON(b []byte) error {
+	type plain HeroHero
+	if err := json.Unmarshal(b, (*plain)(x)); err != nil {
+		return err
+	}
+	switch x.Typename {
+	case "Human":
+		x.OnHuman = new(HeroHeroOnHuman)
+		if err := json.Unmarshal(b, x.OnHuman); err != nil {
+			return err
+		}
+	}
+	switch x.Typename {
+	case "Droid":
+		x.OnDroid = new(HeroHeroOnDroid)
+		if err := json.Unmarshal(b, x.OnDroid); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type HeroHeroFriends struct {
+	Typename string `json:"__typename"`
+	Name     string `json:"name"`
+}
+
+type HeroHeroOnHuman struct {
+	Height    float64                    `json:"height"`
+	Mass      *float64                   `json:"mass"`
+	Starships []HeroHeroOnHumanStarships `json:"starships"`
+}
+
+type HeroHeroOnHumanStarships struct {
+	Name string `json:"name"`
+}
+
+type HeroHeroOnDroid struct {
+	PrimaryFunction *string `json:"primaryFunction"`
+}
+
+type ReviewsResponse struct {
+	Reviews []ReviewsReviews `json:"reviews"`
+}
+
+type ReviewsReviews struct {
+	ID         string     `json:"id"`
+	Stars      int        `json:"stars"`
+	Commentary *string    `json:"commentary"`
+	Time       *time.Time `json:"time"`
+}
+
+type ReviewsPageResponse struct {
+	ReviewsConnection ReviewsPageReviewsConnection `json:"reviewsConnection"`
+}
+
+type ReviewsPageReviewsConnection struct {
+	TotalCount   int                                 `json:"totalCount"`
+	AverageStars *float64                            `json:"averageStars"`
+	Edges        []ReviewsPageReviewsConnectionEdges `json:"edges"`
+}
+
+type ReviewsPageReviewsConnectionEdges struct {
+	Node ReviewsPageReviewsConnectionEdgesNode `json:"node"`
+}
+
+type ReviewsPageReviewsConnectionEdgesNode struct {
+	Stars int `json:"stars"`
+}
+
+type CreateReviewResponse struct {
+	CreateReview *CreateReviewCreateReview `json:"createReview"`
+}
+
+type CreateReviewCreateReview struct {
+	ID         string     `json:"id"`
+	Stars      int        `json:"stars"`
+	Commentary *string    `json:"commentary"`
+	Time       *time.Time `json:"time"`
+}
+
+type SearchResponse struct {
+	Search []SearchSearch `json:"search"`
+}
+
+type SearchSearch struct {
+	Typename    string                   `json:"__typename"`
+	OnCharacter *SearchSearchOnCharacter `json:"-"`
+	OnHuman     *SearchSearchOnHuman     `json:"-"`
+	OnStarship  *SearchSearchOnStarship  `json:"-"`
+}
+
+func (x *SearchSearch) UnmarshalJSON(b []byte) error {
+	type plain SearchSearch
+	if err := json.Unmarshal(b, (*plain)(x)); err != nil {
+		return err
+	}
+	switch x.Typename {
+	case "Droid", "Human":
+		x.OnCharacter = new(SearchSearchOnCharacter)
+		if err := json.Unmarshal(b, x.OnCharacter); err != nil {
+			return err
+		}
+	}
+	switch x.Typename {
+	case "Human":
+		x.OnHuman = new(SearchSearchOnHuman)
+		if err := json.Unmarshal(b, x.OnHuman); err != nil {
+			return err
+		}
+	}
+	switch x.Typename {
+	case "Starship":
+		x.OnStarship = new(SearchSearchOnStarship)
+		if err := json.Unmarshal(b, x.OnStarship); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type SearchSearchOnCharacter struct {
+	Name      string    `json:"name"`
+	AppearsIn []Episode `json:"appearsIn"`
+}
+
+type SearchSearchOnHuman struct {
+	Height float64 `json:"height"`
+}
+
+type SearchSearchOnStarship struct {
+	Name   string  `json:"name"`
+	Length float64 `json:"length"`
+}
+
+// ReviewInput is the input type ReviewInput.
+type ReviewInput struct {
+	Stars      int        `json:"stars"`
+	Commentary *string    `json:"commentary,omitempty"`
+	Time       *time.Time `json:"time,omitempty"`
+}
+
+// ReviewOrder is the input type ReviewOrder.
+type ReviewOrder struct {
+	Field     ReviewOrderField `json:"field"`
+	Direction *OrderDirection  `json:"direction,omitempty"`
+}
+
+// Episode is the enum Episode.
+type Episode string
+
+const (
+	EpisodeNewhope Episode = "NEWHOPE"
+	EpisodeEmpire  Episode = "EMPIRE"
+	EpisodeJedi    Episode = "JEDI"
+)
+
+// OrderDirection is the enum OrderDirection.
+type OrderDirection string
+
+const (
+	OrderDirectionAsc  OrderDirection = "ASC"
+	OrderDirectionDesc OrderDirection = "DESC"
+)
+
+// ReviewOrderField is the enum ReviewOrderField.
+type ReviewOrderField string
+
+const (
+	ReviewOrderFieldTime  ReviewOrderField = "TIME"
+	ReviewOrderFieldStars ReviewOrderField = "STARS"
+)
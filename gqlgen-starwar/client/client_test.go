@@ -0,0 +1,226 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"graphql/apperr"
+	"graphql/dataset"
+	"graphql/gqlclient"
+	"graphql/gqlgen-starwar/client"
+	"graphql/gqlgen-starwar/generated"
+	"graphql/gqlgen-starwar/resolve"
+	"math"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql/handler"
+	"github.com/99designs/gqlgen/graphql/handler/transport"
+)
+
+// checks are run in order against the same server, so that the reviews
+// created by one are seen by the next.
+var checks = []struct {
+	name  string
+	check func(ctx context.Context, c *gqlclient.Client) error
+}{
+	{"hero with the default episode", checkDefaultHero},
+	{"hero of an episode", checkHero},
+	{"search across a union", checkSearch},
+	{"friends connection", checkFriends},
+	{"unknown character", checkNotFound},
+	{"create and list reviews", checkReviews},
+	{"reviews connection ordered by an input", checkReviewsPage},
+}
+
+// TestE2E checks the client end to end: it serves gqlgen-starwar with
+// httptest, sends every operation of the client and checks the decoded
+// responses.
+func TestE2E(t *testing.T) {
+	srv := handler.New(generated.NewExecutableSchema(resolve.NewResolver(dataset.Default())))
+	srv.AddTransport(transport.POST{})
+	srv.SetErrorPresenter(apperr.ErrorPresenter)
+	ts := httptest.NewServer(resolve.LoaderMiddleware(srv))
+	defer ts.Close()
+
+	c := gqlclient.New(ts.URL)
+	for _, check := range checks {
+		check := check
+		t.Run(check.name, func(t *testing.T) {
+			if err := check.check(context.Background(), c); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func checkDefaultHero(ctx context.Context, c *gqlclient.Client) error {
+	resp, err := client.Hero(ctx, c, nil)
+	if err != nil {
+		return err
+	}
+	hero := resp.Hero
+	if hero == nil || hero.Name != "R2-D2" || hero.Typename != "Droid" {
+		return fmt.Errorf("got hero %+v, want R2-D2", hero)
+	}
+	if hero.OnHuman != nil || hero.OnDroid == nil {
+		return fmt.Errorf("got fragments on Human %v and on Droid %v, want only on Droid", hero.OnHuman, hero.OnDroid)
+	}
+	if f := hero.OnDroid.PrimaryFunction; f == nil || *f != "Astromech" {
+		return fmt.Errorf("got primary function %v, want Astromech", f)
+	}
+	if len(hero.Friends) == 0 || hero.Friends[0].Name == "" {
+		return fmt.Errorf("got friends %+v, want named friends", hero.Friends)
+	}
+	return nil
+}
+
+func checkHero(ctx context.Context, c *gqlclient.Client) error {
+	episode := client.EpisodeEmpire
+	resp, err := client.Hero(ctx, c, &episode)
+	if err != nil {
+		return err
+	}
+	hero := resp.Hero
+	if hero == nil || hero.Name != "Luke Skywalker" || hero.ID == "" {
+		return fmt.Errorf("got hero %+v, want Luke Skywalker", hero)
+	}
+	if len(hero.AppearsIn) != 3 || hero.AppearsIn[0] != client.EpisodeNewhope {
+		return fmt.Errorf("got appearsIn %v, want the three episodes", hero.AppearsIn)
+	}
+	human := hero.OnHuman
+	if human == nil || hero.OnDroid != nil {
+		return fmt.Errorf("got fragments on Human %v and on Droid %v, want only on Human", human, hero.OnDroid)
+	}
+	if math.Abs(human.Height-1.72*3.28084) > 1e-6 {
+		return fmt.Errorf("got height %v feet, want %v", human.Height, 1.72*3.28084)
+	}
+	if human.Mass == nil || *human.Mass != 77 {
+		return fmt.Errorf("got mass %v, want 77", human.Mass)
+	}
+	if len(human.Starships) != 2 || human.Starships[0].Name != "X-Wing" {
+		return fmt.Errorf("got starships %+v, want the X-Wing and the Imperial shuttle", human.Starships)
+	}
+	return nil
+}
+
+func checkSearch(ctx context.Context, c *gqlclient.Client) error {
+	resp, err := client.Search(ctx, c, "o")
+	if err != nil {
+		return err
+	}
+	found := map[string]bool{}
+	for _, r := range resp.Search {
+		switch r.Typename {
+		case "Human":
+			if r.OnCharacter == nil || r.OnHuman == nil || r.OnStarship != nil {
+				return fmt.Errorf("got fragments %+v for a human", r)
+			}
+			found[r.OnCharacter.Name] = r.OnHuman.Height > 0 && len(r.OnCharacter.AppearsIn) > 0
+		case "Starship":
+			if r.OnStarship == nil || r.OnCharacter != nil || r.OnHuman != nil {
+				return fmt.Errorf("got fragments %+v for a starship", r)
+			}
+			found[r.OnStarship.Name] = r.OnStarship.Length > 0
+		}
+	}
+	for _, name := range []string{"Han Solo", "Millennium Falcon"} {
+		if !found[name] {
+			return fmt.Errorf("%s is missing or incomplete in %v", name, found)
+		}
+	}
+	return nil
+}
+
+func checkFriends(ctx context.Context, c *gqlclient.Client) error {
+	first := 2
+	resp, err := client.Friends(ctx, c, "1000", &first, nil)
+	if err != nil {
+		return err
+	}
+	if resp.Character == nil {
+		return errors.New("got no character")
+	}
+	conn := resp.Character.FriendsConnection
+	if conn.TotalCount != 4 || len(conn.Edges) != 2 || !conn.PageInfo.HasNextPage {
+		return fmt.Errorf("got %+v, want the first 2 of 4 friends", conn)
+	}
+
+	resp, err = client.Friends(ctx, c, "1000", &first, conn.PageInfo.EndCursor)
+	if err != nil {
+		return err
+	}
+	next := resp.Character.FriendsConnection
+	if len(next.Edges) != 2 || next.PageInfo.HasNextPage || next.Edges[0].Node.Name == conn.Edges[0].Node.Name {
+		return fmt.Errorf("got %+v, want the last 2 friends", next)
+	}
+	return nil
+}
+
+func checkNotFound(ctx context.Context, c *gqlclient.Client) error {
+	resp, err := client.Friends(ctx, c, "9999", nil, nil)
+	var errs gqlclient.Errors
+	if !errors.As(err, &errs) || errs[0].Code() != string(apperr.NotFound) {
+		return fmt.Errorf("got error %v, want a %s error", err, apperr.NotFound)
+	}
+	if resp.Character != nil {
+		return fmt.Errorf("got character %+v, want none", resp.Character)
+	}
+	return nil
+}
+
+func checkReviews(ctx context.Context, c *gqlclient.Client) error {
+	since := time.Now().Add(-time.Minute).Truncate(time.Second)
+	commentary := "This is a great movie!"
+	created, err := client.CreateReview(ctx, c, client.EpisodeJedi, client.ReviewInput{
+		Stars:      5,
+		Commentary: &commentary,
+	})
+	if err != nil {
+		return err
+	}
+	review := created.CreateReview
+	if review == nil || review.ID == "" || review.Stars != 5 || review.Time == nil || review.Time.Before(since) {
+		return fmt.Errorf("got review %+v, want a 5 stars review posted now", review)
+	}
+
+	resp, err := client.Reviews(ctx, c, client.EpisodeJedi, &since)
+	if err != nil {
+		return err
+	}
+	if len(resp.Reviews) != 1 || resp.Reviews[0].ID != review.ID || *resp.Reviews[0].Commentary != commentary {
+		return fmt.Errorf("got reviews %+v since %v, want the created one", resp.Reviews, since)
+	}
+	return nil
+}
+
+func checkReviewsPage(ctx context.Context, c *gqlclient.Client) error {
+	for _, stars := range []int{2, 4} {
+		if _, err := client.CreateReview(ctx, c, client.EpisodeJedi, client.ReviewInput{Stars: stars}); err != nil {
+			return err
+		}
+	}
+	// The direction could be left out for its default, but the validation
+	// of variables in gqlparser v2.1.0 ignores the defaults of input fields.
+	asc := client.OrderDirectionAsc
+	resp, err := client.ReviewsPage(ctx, c, client.EpisodeJedi, nil, &client.ReviewOrder{
+		Field:     client.ReviewOrderFieldStars,
+		Direction: &asc,
+	})
+	if err != nil {
+		return err
+	}
+	conn := resp.ReviewsConnection
+	var stars []int
+	for _, e := range conn.Edges {
+		stars = append(stars, e.Node.Stars)
+	}
+	if conn.TotalCount != 3 || fmt.Sprint(stars) != "[2 4 5]" {
+		return fmt.Errorf("got %d reviews with stars %v, want [2 4 5]", conn.TotalCount, stars)
+	}
+	if conn.AverageStars == nil || math.Abs(*conn.AverageStars-11.0/3) > 1e-9 {
+		return fmt.Errorf("got average %v, want %v", conn.AverageStars, 11.0/3)
+	}
+	return nil
+}
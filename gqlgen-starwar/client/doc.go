@@ -0,0 +1,6 @@
+// Package client is a typed client of the Star Wars API served by
+// gqlgen-starwar, generated from the operations in the operations
+// directory. Add or edit an operation there and run go generate.
+package client
+
+//go:generate go run ../../clientgen -schema ../schema.graphql -operations operations -o client.go -package client -scalar Time=time.Time
@@ -13,6 +13,10 @@ type Character interface {
 	IsCharacter()
 }
 
+type Node interface {
+	IsNode()
+}
+
 type SearchResult interface {
 	IsSearchResult()
 }
@@ -27,6 +31,7 @@ type Droid struct {
 }
 
 func (Droid) IsCharacter()    {}
+func (Droid) IsNode()         {}
 func (Droid) IsSearchResult() {}
 
 type FriendsConnection struct {
@@ -53,15 +58,18 @@ type Human struct {
 }
 
 func (Human) IsCharacter()    {}
+func (Human) IsNode()         {}
 func (Human) IsSearchResult() {}
 
 type PageInfo struct {
-	StartCursor string `json:"startCursor"`
-	EndCursor   string `json:"endCursor"`
-	HasNextPage bool   `json:"hasNextPage"`
+	StartCursor     *string `json:"startCursor"`
+	EndCursor       *string `json:"endCursor"`
+	HasNextPage     bool    `json:"hasNextPage"`
+	HasPreviousPage bool    `json:"hasPreviousPage"`
 }
 
 type Review struct {
+	ID         string     `json:"id"`
 	Stars      int        `json:"stars"`
 	Commentary *string    `json:"commentary"`
 	Time       *time.Time `json:"time"`
@@ -73,6 +81,29 @@ type ReviewInput struct {
 	Time       *time.Time `json:"time"`
 }
 
+type ReviewOrder struct {
+	Field     ReviewOrderField `json:"field"`
+	Direction OrderDirection   `json:"direction"`
+}
+
+type ReviewsConnection struct {
+	TotalCount     int            `json:"totalCount"`
+	Edges          []*ReviewsEdge `json:"edges"`
+	PageInfo       *PageInfo      `json:"pageInfo"`
+	AverageStars   *float64       `json:"averageStars"`
+	StarsHistogram []*StarsCount  `json:"starsHistogram"`
+}
+
+type ReviewsEdge struct {
+	Cursor string  `json:"cursor"`
+	Node   *Review `json:"node"`
+}
+
+type StarsCount struct {
+	Stars int `json:"stars"`
+	Count int `json:"count"`
+}
+
 type Starship struct {
 	ID      string  `json:"id"`
 	Name    string  `json:"name"`
@@ -80,6 +111,7 @@ type Starship struct {
 	History [][]int `json:"history"`
 }
 
+func (Starship) IsNode()         {}
 func (Starship) IsSearchResult() {}
 
 type Episode string
@@ -165,3 +197,85 @@ func (e *LengthUnit) UnmarshalGQL(v interface{}) error {
 func (e LengthUnit) MarshalGQL(w io.Writer) {
 	fmt.Fprint(w, strconv.Quote(e.String()))
 }
+
+type OrderDirection string
+
+const (
+	OrderDirectionAsc  OrderDirection = "ASC"
+	OrderDirectionDesc OrderDirection = "DESC"
+)
+
+var AllOrderDirection = []OrderDirection{
+	OrderDirectionAsc,
+	OrderDirectionDesc,
+}
+
+func (e OrderDirection) IsValid() bool {
+	switch e {
+	case OrderDirectionAsc, OrderDirectionDesc:
+		return true
+	}
+	return false
+}
+
+func (e OrderDirection) String() string {
+	return string(e)
+}
+
+func (e *OrderDirection) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = OrderDirection(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid OrderDirection", str)
+	}
+	return nil
+}
+
+func (e OrderDirection) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type ReviewOrderField string
+
+const (
+	ReviewOrderFieldTime  ReviewOrderField = "TIME"
+	ReviewOrderFieldStars ReviewOrderField = "STARS"
+)
+
+var AllReviewOrderField = []ReviewOrderField{
+	ReviewOrderFieldTime,
+	ReviewOrderFieldStars,
+}
+
+func (e ReviewOrderField) IsValid() bool {
+	switch e {
+	case ReviewOrderFieldTime, ReviewOrderFieldStars:
+		return true
+	}
+	return false
+}
+
+func (e ReviewOrderField) String() string {
+	return string(e)
+}
+
+func (e *ReviewOrderField) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = ReviewOrderField(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid ReviewOrderField", str)
+	}
+	return nil
+}
+
+func (e ReviewOrderField) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
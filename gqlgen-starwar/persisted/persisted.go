@@ -0,0 +1,112 @@
+// Package persisted serves operations from a manifest of persisted queries
+// and, in strict mode, refuses to execute anything else.
+package persisted
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/99designs/gqlgen/graphql/errcode"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+const (
+	errPersistedQueryNotFound     = "PersistedQueryNotFound"
+	errPersistedQueryNotFoundCode = "PERSISTED_QUERY_NOT_FOUND"
+	errOperationNotAllowedCode    = "OPERATION_NOT_ALLOWED"
+)
+
+// Manifest maps the SHA-256 hash of every known operation to its text.
+type Manifest map[string]string
+
+// LoadManifest reads a JSON object of hashes to queries from path and checks
+// that every hash matches its query.
+func LoadManifest(path string) (Manifest, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var m Manifest
+	if err := json.Unmarshal(b, &m); err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+	for hash, query := range m {
+		if Hash(query) != hash {
+			return nil, fmt.Errorf("%s: hash %s does not match its query", path, hash)
+		}
+	}
+	return m, nil
+}
+
+// Hash returns the hex encoded SHA-256 hash of query, as sent by Apollo
+// clients in extensions.persistedQuery.sha256Hash.
+func Hash(query string) string {
+	b := sha256.Sum256([]byte(query))
+	return hex.EncodeToString(b[:])
+}
+
+// Queries resolves persisted query hashes from the manifest. Hashes it does
+// not know are left to the automatic persisted query extension, unless
+// Strict is set, in which case only operations of the manifest are executed.
+// It must be used before extension.AutomaticPersistedQuery.
+type Queries struct {
+	Manifest Manifest
+	Strict   bool
+}
+
+var _ interface {
+	graphql.OperationParameterMutator
+	graphql.HandlerExtension
+} = Queries{}
+
+func (q Queries) ExtensionName() string {
+	return "PersistedQueries"
+}
+
+func (q Queries) Validate(schema graphql.ExecutableSchema) error {
+	if q.Strict && len(q.Manifest) == 0 {
+		return fmt.Errorf("PersistedQueries in strict mode needs a manifest")
+	}
+	return nil
+}
+
+func (q Queries) MutateOperationParameters(ctx context.Context, rawParams *graphql.RawParams) *gqlerror.Error {
+	var hash string
+	if ext := rawParams.Extensions["persistedQuery"]; ext != nil {
+		m, ok := ext.(map[string]interface{})
+		if !ok {
+			return gqlerror.Errorf("invalid APQ extension data")
+		}
+		hash, _ = m["sha256Hash"].(string)
+	}
+
+	if hash != "" {
+		query, ok := q.Manifest[hash]
+		if ok {
+			if rawParams.Query != "" && rawParams.Query != query {
+				return gqlerror.Errorf("provided APQ hash does not match query")
+			}
+			rawParams.Query = query
+			return nil
+		}
+		if q.Strict && rawParams.Query == "" {
+			err := gqlerror.Errorf(errPersistedQueryNotFound)
+			errcode.Set(err, errPersistedQueryNotFoundCode)
+			return err
+		}
+	}
+
+	if q.Strict {
+		if _, ok := q.Manifest[Hash(rawParams.Query)]; !ok {
+			err := gqlerror.Errorf("operation is not in the persisted query manifest")
+			errcode.Set(err, errOperationNotAllowedCode)
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package resolve
+
+import (
+	"context"
+	"graphql/gqlgen-starwar/model"
+	"sync"
+)
+
+// reviewBufferSize is how many reviews a subscriber may fall behind before
+// new reviews are dropped for it.
+const reviewBufferSize = 16
+
+type reviewSubscriber struct {
+	episode *model.Episode
+	ch      chan *model.Review
+}
+
+// reviewBroadcaster fans newly created reviews out to every subscriber
+// listening on the matching episode.
+type reviewBroadcaster struct {
+	mu          sync.Mutex
+	subscribers map[*reviewSubscriber]struct{}
+}
+
+func newReviewBroadcaster() *reviewBroadcaster {
+	return &reviewBroadcaster{
+		subscribers: map[*reviewSubscriber]struct{}{},
+	}
+}
+
+// subscribe registers a subscriber for the episode, or for every episode if
+// episode is nil. The returned channel is closed once ctx is done, which
+// happens when the client disconnects.
+func (b *reviewBroadcaster) subscribe(ctx context.Context, episode *model.Episode) <-chan *model.Review {
+	s := &reviewSubscriber{
+		episode: episode,
+		ch:      make(chan *model.Review, reviewBufferSize),
+	}
+
+	b.mu.Lock()
+	b.subscribers[s] = struct{}{}
+	b.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		b.mu.Lock()
+		delete(b.subscribers, s)
+		close(s.ch)
+		b.mu.Unlock()
+	}()
+	return s.ch
+}
+
+// publish delivers the review without blocking; a subscriber whose buffer is
+// full misses it rather than stalling the mutation.
+func (b *reviewBroadcaster) publish(episode model.Episode, review *model.Review) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for s := range b.subscribers {
+		if s.episode != nil && *s.episode != episode {
+			continue
+		}
+		select {
+		case s.ch <- review:
+		default:
+		}
+	}
+}
@@ -0,0 +1,182 @@
+package resolve
+
+import (
+	"context"
+	"graphql/gqlgen-starwar/model"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// loaderWait is how long a loader collects keys before fetching them in one
+// batch. It is a variable for tests to widen.
+var loaderWait = time.Millisecond
+
+type loadersKey struct{}
+
+// loaders holds the request-scoped loaders, built on first use so that the
+// middleware does not need to know about the resolver.
+type loaders struct {
+	once       sync.Once
+	characters *batchLoader
+	starships  *batchLoader
+}
+
+// LoaderMiddleware gives every request its own loaders, so that character and
+// starship lookups made while resolving one query are batched and de-duplicated.
+func LoaderMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		ctx := context.WithValue(req.Context(), loadersKey{}, &loaders{})
+		next.ServeHTTP(w, req.WithContext(ctx))
+	})
+}
+
+// FetchCounts reports how many batches have been fetched from the backing
+// data since the resolver was created.
+type FetchCounts struct {
+	Characters int64
+	Starships  int64
+}
+
+type fetchCounter struct {
+	characters int64
+	starships  int64
+}
+
+func (r *Resolver) Fetches() FetchCounts {
+	return FetchCounts{
+		Characters: atomic.LoadInt64(&r.fetches.characters),
+		Starships:  atomic.LoadInt64(&r.fetches.starships),
+	}
+}
+
+// loaders returns the loaders of the current request, or fresh ones when the
+// request did not pass through LoaderMiddleware.
+func (r *Resolver) loaders(ctx context.Context) *loaders {
+	l, ok := ctx.Value(loadersKey{}).(*loaders)
+	if !ok {
+		l = &loaders{}
+	}
+	l.once.Do(func() {
+		l.characters = newBatchLoader(r.fetchCharacters)
+		l.starships = newBatchLoader(r.fetchStarships)
+	})
+	return l
+}
+
+func (r *Resolver) fetchCharacters(ids []string) []interface{} {
+	atomic.AddInt64(&r.fetches.characters, 1)
+	result := make([]interface{}, len(ids))
+	for i, id := range ids {
+		if h, ok := r.humans[id]; ok {
+			result[i] = &h
+		} else if d, ok := r.droid[id]; ok {
+			result[i] = &d
+		}
+	}
+	return result
+}
+
+func (r *Resolver) fetchStarships(ids []string) []interface{} {
+	atomic.AddInt64(&r.fetches.starships, 1)
+	result := make([]interface{}, len(ids))
+	for i, id := range ids {
+		if s, ok := r.starships[id]; ok {
+			result[i] = &s
+		}
+	}
+	return result
+}
+
+func (r *Resolver) loadCharacters(ctx context.Context, ids []string) []model.Character {
+	values := r.loaders(ctx).characters.loadAll(ids)
+	result := make([]model.Character, len(values))
+	for i, v := range values {
+		if c, ok := v.(model.Character); ok {
+			result[i] = c
+		}
+	}
+	return result
+}
+
+func (r *Resolver) loadStarships(ctx context.Context, ids []string) []*model.Starship {
+	values := r.loaders(ctx).starships.loadAll(ids)
+	result := make([]*model.Starship, len(values))
+	for i, v := range values {
+		if s, ok := v.(*model.Starship); ok {
+			result[i] = s
+		}
+	}
+	return result
+}
+
+// batchLoader collects the keys requested within loaderWait of each other,
+// fetches them with a single call and caches the result for later loads.
+type batchLoader struct {
+	fetch func(keys []string) []interface{}
+
+	mu    sync.Mutex
+	cache map[string]*loaderResult
+	batch *loaderBatch
+}
+
+type loaderResult struct {
+	done  chan struct{}
+	value interface{}
+}
+
+type loaderBatch struct {
+	keys    []string
+	results []*loaderResult
+}
+
+func newBatchLoader(fetch func(keys []string) []interface{}) *batchLoader {
+	return &batchLoader{
+		fetch: fetch,
+		cache: map[string]*loaderResult{},
+	}
+}
+
+func (l *batchLoader) loadAll(keys []string) []interface{} {
+	results := make([]*loaderResult, len(keys))
+	for i, key := range keys {
+		results[i] = l.enqueue(key)
+	}
+	values := make([]interface{}, len(keys))
+	for i, res := range results {
+		<-res.done
+		values[i] = res.value
+	}
+	return values
+}
+
+func (l *batchLoader) enqueue(key string) *loaderResult {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if res, ok := l.cache[key]; ok {
+		return res
+	}
+	res := &loaderResult{done: make(chan struct{})}
+	l.cache[key] = res
+	if l.batch == nil {
+		l.batch = &loaderBatch{}
+		go l.dispatch(l.batch)
+	}
+	l.batch.keys = append(l.batch.keys, key)
+	l.batch.results = append(l.batch.results, res)
+	return res
+}
+
+func (l *batchLoader) dispatch(b *loaderBatch) {
+	time.Sleep(loaderWait)
+	l.mu.Lock()
+	l.batch = nil
+	l.mu.Unlock()
+
+	values := l.fetch(b.keys)
+	for i, res := range b.results {
+		res.value = values[i]
+		close(res.done)
+	}
+}
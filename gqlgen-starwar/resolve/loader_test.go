@@ -0,0 +1,97 @@
+package resolve
+
+import (
+	"bytes"
+	"encoding/json"
+	"graphql/apperr"
+	"graphql/dataset"
+	"graphql/gqlgen-starwar/generated"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql/handler"
+	"github.com/99designs/gqlgen/graphql/handler/transport"
+)
+
+const nestedFriends = `{ hero { friends { friends { name } } } }`
+
+// serve starts a server of the resolver, with the loaders of
+// LoaderMiddleware if batched, and returns the resolver.
+func serve(t *testing.T, batched bool) (*Resolver, *httptest.Server) {
+	t.Helper()
+	ds, err := dataset.Open("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg := NewResolver(ds)
+	srv := handler.New(generated.NewExecutableSchema(cfg))
+	srv.AddTransport(transport.POST{})
+	srv.SetErrorPresenter(apperr.ErrorPresenter)
+	var h http.Handler = srv
+	if batched {
+		h = LoaderMiddleware(srv)
+	}
+	ts := httptest.NewServer(h)
+	t.Cleanup(ts.Close)
+	return cfg.Resolvers.(*Resolver), ts
+}
+
+func query(t *testing.T, ts *httptest.Server, q string) {
+	t.Helper()
+	body, _ := json.Marshal(map[string]string{"query": q})
+	resp, err := http.Post(ts.URL, "application/json", bytes.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	var result struct {
+		Data struct {
+			Hero struct {
+				Friends []struct {
+					Friends []struct {
+						Name string `json:"name"`
+					} `json:"friends"`
+				} `json:"friends"`
+			} `json:"hero"`
+		} `json:"data"`
+		Errors []json.RawMessage `json:"errors"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Errors) > 0 {
+		t.Fatalf("errors: %s", result.Errors)
+	}
+	if len(result.Data.Hero.Friends) != 3 {
+		t.Fatalf("got %d friends of the hero, want 3", len(result.Data.Hero.Friends))
+	}
+}
+
+func TestFetches(t *testing.T) {
+	// Wide enough for the friends of every friend to be requested within it
+	// even on a loaded machine.
+	defer func(wait time.Duration) { loaderWait = wait }(loaderWait)
+	loaderWait = 100 * time.Millisecond
+
+	tests := []struct {
+		name    string
+		batched bool
+		want    FetchCounts
+	}{
+		// One fetch for the friends of the hero, one for all of theirs.
+		{"loaders", true, FetchCounts{Characters: 2}},
+		// One fetch for the friends of the hero, one per friend of theirs.
+		{"n+1", false, FetchCounts{Characters: 1 + 3}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r, ts := serve(t, test.batched)
+			query(t, ts, nestedFriends)
+			if got := r.Fetches(); got != test.want {
+				t.Errorf("got %+v fetches, want %+v", got, test.want)
+			}
+		})
+	}
+}
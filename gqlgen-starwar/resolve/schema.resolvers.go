@@ -5,11 +5,12 @@ package resolve
 
 import (
 	"context"
-	"encoding/base64"
-	"errors"
-	"fmt"
+	"graphql/apperr"
+	"graphql/globalid"
 	"graphql/gqlgen-starwar/generated"
 	"graphql/gqlgen-starwar/model"
+	"graphql/pagination"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -19,12 +20,16 @@ type droidResolver struct {
 	*Resolver
 }
 
+func (r *droidResolver) ID(ctx context.Context, obj *model.Droid) (string, error) {
+	return globalid.Encode("Droid", obj.ID), nil
+}
+
 func (r *droidResolver) Friends(ctx context.Context, obj *model.Droid) ([]model.Character, error) {
 	return r.resolveCharacters(ctx, obj.Friends)
 }
 
-func (r *droidResolver) FriendsConnection(ctx context.Context, obj *model.Droid, first *int, after *string) (*model.FriendsConnection, error) {
-	return r.resolveFriendConnection(ctx, obj.Friends, first, after)
+func (r *droidResolver) FriendsConnection(ctx context.Context, obj *model.Droid, first *int, after *string, last *int, before *string) (*model.FriendsConnection, error) {
+	return r.resolveFriendConnection(ctx, obj.Friends, pagination.Args{First: first, After: after, Last: last, Before: before})
 }
 
 type friendsConnectionResolver struct {
@@ -32,18 +37,19 @@ type friendsConnectionResolver struct {
 }
 
 func (r *friendsConnectionResolver) Edges(ctx context.Context, obj *model.FriendsConnection) ([]*model.FriendsEdge, error) {
-	friends, err := r.resolveCharacters(ctx, obj.Friends)
+	nodes := make([]model.Character, len(obj.Edges))
+	for i, edge := range obj.Edges {
+		nodes[i] = edge.Node
+	}
+	friends, err := r.resolveCharacters(ctx, nodes)
 	if err != nil {
 		return nil, err
 	}
 
-	to, _ := decodeCursor(obj.PageInfo.EndCursor)
-	from, _ := decodeCursor(obj.PageInfo.StartCursor)
-
-	edges := make([]*model.FriendsEdge, to-from)
-	for i := range edges {
+	edges := make([]*model.FriendsEdge, len(obj.Edges))
+	for i, edge := range obj.Edges {
 		edges[i] = &model.FriendsEdge{
-			Cursor: encodeCursor(from),
+			Cursor: edge.Cursor,
 			Node:   friends[i],
 		}
 	}
@@ -58,6 +64,10 @@ type humanResolver struct {
 	*Resolver
 }
 
+func (r *humanResolver) ID(ctx context.Context, obj *model.Human) (string, error) {
+	return globalid.Encode("Human", obj.ID), nil
+}
+
 func (r *humanResolver) Height(ctx context.Context, obj *model.Human, unit *model.LengthUnit) (float64, error) {
 	if unit == nil || *unit == model.LengthUnitMeter {
 		return obj.Height, nil
@@ -74,19 +84,19 @@ func (r *humanResolver) Friends(ctx context.Context, obj *model.Human) ([]model.
 	return r.resolveCharacters(ctx, obj.Friends)
 }
 
-func (r *humanResolver) FriendsConnection(ctx context.Context, obj *model.Human, first *int, after *string) (*model.FriendsConnection, error) {
-	return r.resolveFriendConnection(ctx, obj.Friends, first, after)
+func (r *humanResolver) FriendsConnection(ctx context.Context, obj *model.Human, first *int, after *string, last *int, before *string) (*model.FriendsConnection, error) {
+	return r.resolveFriendConnection(ctx, obj.Friends, pagination.Args{First: first, After: after, Last: last, Before: before})
 }
 
 func (r *humanResolver) Starships(ctx context.Context, obj *model.Human) ([]*model.Starship, error) {
+	ids := make([]string, len(obj.Starships))
+	for i, s := range obj.Starships {
+		ids[i] = s.ID
+	}
 	var result []*model.Starship
-	for _, id := range obj.Starships {
-		char, err := r.Query().Starship(ctx, id.ID)
-		if err != nil {
-			return nil, err
-		}
-		if char != nil {
-			result = append(result, char)
+	for _, s := range r.loadStarships(ctx, ids) {
+		if s != nil {
+			result = append(result, s)
 		}
 	}
 	return result, nil
@@ -94,14 +104,63 @@ func (r *humanResolver) Starships(ctx context.Context, obj *model.Human) ([]*mod
 
 func (r *mutationResolver) CreateReview(ctx context.Context, episode model.Episode, review model.ReviewInput) (*model.Review, error) {
 	now := time.Now()
-	time.Sleep(1 * time.Second)
+	if !validateReview(ctx, []interface{}{"review"}, &review, now) {
+		return nil, nil
+	}
+	return r.addReviews(episode, []*model.ReviewInput{&review}, now)[0], nil
+}
+
+func (r *mutationResolver) CreateReviews(ctx context.Context, episode model.Episode, reviews []*model.ReviewInput) ([]*model.Review, error) {
+	now := time.Now()
+	valid := true
+	for i, review := range reviews {
+		if !validateReview(ctx, []interface{}{"reviews", i}, review, now) {
+			valid = false
+		}
+	}
+	if !valid {
+		return nil, nil
+	}
+	return r.addReviews(episode, reviews, now), nil
+}
+
+func (r *mutationResolver) UpdateReview(ctx context.Context, id string, review model.ReviewInput) (*model.Review, error) {
+	if !validateReview(ctx, []interface{}{"review"}, &review, time.Now()) {
+		return nil, nil
+	}
 
-	reviewRes := model.Review{}
-	reviewRes.Commentary = review.Commentary
-	reviewRes.Stars = review.Stars
-	reviewRes.Time = &now
-	r.reviews[episode] = append(r.reviews[episode], &reviewRes)
-	return &reviewRes, nil
+	r.reviewsMu.Lock()
+	defer r.reviewsMu.Unlock()
+	episode, i, ok := r.findReview(id)
+	if !ok {
+		return nil, reviewNotFound(id)
+	}
+	// Reviews are replaced rather than modified, as readers may still hold the old one.
+	updated := &model.Review{
+		ID:         id,
+		Stars:      review.Stars,
+		Commentary: review.Commentary,
+		Time:       r.reviews[episode][i].Time,
+	}
+	if review.Time != nil {
+		updated.Time = review.Time
+	}
+	r.reviews[episode][i] = updated
+	return updated, nil
+}
+
+func (r *mutationResolver) DeleteReview(ctx context.Context, id string) (*model.Review, error) {
+	r.reviewsMu.Lock()
+	defer r.reviewsMu.Unlock()
+	episode, i, ok := r.findReview(id)
+	if !ok {
+		return nil, reviewNotFound(id)
+	}
+	deleted := r.reviews[episode][i]
+	reviews := make([]*model.Review, 0, len(r.reviews[episode])-1)
+	reviews = append(reviews, r.reviews[episode][:i]...)
+	r.reviews[episode] = append(reviews, r.reviews[episode][i+1:]...)
+	return deleted, nil
 }
 
 func (r *queryResolver) Hero(ctx context.Context, episode *model.Episode) (model.Character, error) {
@@ -112,19 +171,75 @@ func (r *queryResolver) Hero(ctx context.Context, episode *model.Episode) (model
 }
 
 func (r *queryResolver) Reviews(ctx context.Context, episode model.Episode, since *time.Time) ([]*model.Review, error) {
+	r.reviewsMu.RLock()
+	reviews := make([]*model.Review, len(r.reviews[episode]))
+	copy(reviews, r.reviews[episode])
+	r.reviewsMu.RUnlock()
 	if since == nil {
-		return r.reviews[episode], nil
+		return reviews, nil
 	}
 
 	var filtered []*model.Review
-	for _, rev := range r.reviews[episode] {
-		if rev.Time.After(*since) {
+	for _, rev := range reviews {
+		// Reviews without a time are not known to be recent.
+		if rev.Time != nil && rev.Time.After(*since) {
 			filtered = append(filtered, rev)
 		}
 	}
 	return filtered, nil
 }
 
+func (r *queryResolver) ReviewsConnection(ctx context.Context, episode model.Episode, first *int, after *string, last *int, before *string, orderBy *model.ReviewOrder) (*model.ReviewsConnection, error) {
+	r.reviewsMu.RLock()
+	reviews := make([]*model.Review, len(r.reviews[episode]))
+	copy(reviews, r.reviews[episode])
+	r.reviewsMu.RUnlock()
+
+	if orderBy != nil {
+		sortReviews(reviews, *orderBy)
+	}
+	window, err := pagination.Slice(len(reviews), pagination.Args{First: first, After: after, Last: last, Before: before})
+	if err != nil {
+		return nil, err
+	}
+
+	edges := make([]*model.ReviewsEdge, 0, window.To-window.From)
+	for i := window.From; i < window.To; i++ {
+		edges = append(edges, &model.ReviewsEdge{
+			Cursor: pagination.EncodeCursor(i),
+			Node:   reviews[i],
+		})
+	}
+	pageInfo := &model.PageInfo{
+		HasNextPage:     window.HasNextPage,
+		HasPreviousPage: window.HasPreviousPage,
+	}
+	if window.To > window.From {
+		start, end := pagination.EncodeCursor(window.From), pagination.EncodeCursor(window.To-1)
+		pageInfo.StartCursor, pageInfo.EndCursor = &start, &end
+	}
+
+	conn := &model.ReviewsConnection{
+		TotalCount: len(reviews),
+		Edges:      edges,
+		PageInfo:   pageInfo,
+	}
+	counts := map[int]int{}
+	total := 0
+	for _, rev := range reviews {
+		counts[rev.Stars]++
+		total += rev.Stars
+	}
+	if len(reviews) > 0 {
+		average := float64(total) / float64(len(reviews))
+		conn.AverageStars = &average
+	}
+	for stars := 0; stars <= 5; stars++ {
+		conn.StarsHistogram = append(conn.StarsHistogram, &model.StarsCount{Stars: stars, Count: counts[stars]})
+	}
+	return conn, nil
+}
+
 func (r *queryResolver) Search(ctx context.Context, text string) ([]model.SearchResult, error) {
 	var l []model.SearchResult
 	for _, h := range r.humans {
@@ -146,34 +261,70 @@ func (r *queryResolver) Search(ctx context.Context, text string) ([]model.Search
 }
 
 func (r *queryResolver) Character(ctx context.Context, id string) (model.Character, error) {
-	if h, ok := r.humans[id]; ok {
+	key, err := globalid.Key(id, "Human", "Droid")
+	if err != nil {
+		return nil, err
+	}
+	if h, ok := r.humans[key]; ok {
 		return &h, nil
 	}
-	if d, ok := r.droid[id]; ok {
+	if d, ok := r.droid[key]; ok {
 		return &d, nil
 	}
-	return nil, nil
+	return nil, apperr.NotFoundf("character %q not found", id)
 }
 
 func (r *queryResolver) Droid(ctx context.Context, id string) (*model.Droid, error) {
-	if d, ok := r.droid[id]; ok {
+	key, err := globalid.Key(id, "Droid")
+	if err != nil {
+		return nil, err
+	}
+	if d, ok := r.droid[key]; ok {
 		return &d, nil
 	}
-	return nil, nil
+	return nil, apperr.NotFoundf("droid %q not found", id)
 }
 
 func (r *queryResolver) Human(ctx context.Context, id string) (*model.Human, error) {
-	if h, ok := r.humans[id]; ok {
+	key, err := globalid.Key(id, "Human")
+	if err != nil {
+		return nil, err
+	}
+	if h, ok := r.humans[key]; ok {
 		return &h, nil
 	}
-	return nil, nil
+	return nil, apperr.NotFoundf("human %q not found", id)
 }
 
 func (r *queryResolver) Starship(ctx context.Context, id string) (*model.Starship, error) {
-	if s, ok := r.starships[id]; ok {
+	key, err := globalid.Key(id, "Starship")
+	if err != nil {
+		return nil, err
+	}
+	if s, ok := r.starships[key]; ok {
 		return &s, nil
 	}
-	return nil, nil
+	return nil, apperr.NotFoundf("starship %q not found", id)
+}
+
+func (r *queryResolver) Node(ctx context.Context, id string) (model.Node, error) {
+	return r.resolveNode(id)
+}
+
+func (r *queryResolver) Nodes(ctx context.Context, ids []string) ([]model.Node, error) {
+	nodes := make([]model.Node, len(ids))
+	for i, id := range ids {
+		node, err := r.resolveNode(id)
+		if err != nil {
+			return nil, err
+		}
+		nodes[i] = node
+	}
+	return nodes, nil
+}
+
+func (r *starshipResolver) ID(ctx context.Context, obj *model.Starship) (string, error) {
+	return globalid.Encode("Starship", obj.ID), nil
 }
 
 func (r *starshipResolver) Length(ctx context.Context, obj *model.Starship, unit *model.LengthUnit) (float64, error) {
@@ -183,10 +334,14 @@ func (r *starshipResolver) Length(ctx context.Context, obj *model.Starship, unit
 	case model.LengthUnitFoot:
 		return obj.Length * 3.28084, nil
 	default:
-		return 0, errors.New("invalid unit")
+		return 0, apperr.BadUserInputf("invalid unit %q", *unit)
 	}
 }
 
+func (r *subscriptionResolver) ReviewAdded(ctx context.Context, episode *model.Episode) (<-chan *model.Review, error) {
+	return r.reviewAdded.subscribe(ctx, episode), nil
+}
+
 // Mutation returns generated.MutationResolver implementation.
 func (r *Resolver) Mutation() generated.MutationResolver { return &mutationResolver{r} }
 
@@ -207,78 +362,134 @@ func (r *Resolver) FriendsConnection() generated.FriendsConnectionResolver {
 // Starship returns generated.StarshipResolver implementation.
 func (r *Resolver) Starship() generated.StarshipResolver { return &starshipResolver{r} }
 
+// Subscription returns generated.SubscriptionResolver implementation.
+func (r *Resolver) Subscription() generated.SubscriptionResolver { return &subscriptionResolver{r} }
+
 type mutationResolver struct{ *Resolver }
 type queryResolver struct{ *Resolver }
 
 type starshipResolver struct{ *Resolver }
+type subscriptionResolver struct{ *Resolver }
 
 // !!! WARNING !!!
 // The code below was going to be deleted when updating resolvers. It has been copied here so you have
 // one last chance to move it out of harms way if you want. There are two reasons this happens:
-//  - When renaming or deleting a resolver the old code will be put in here. You can safely delete
-//    it when you're done.
-//  - You have helper methods in this file. Move them out to keep these resolver files clean.
+//   - When renaming or deleting a resolver the old code will be put in here. You can safely delete
+//     it when you're done.
+//   - You have helper methods in this file. Move them out to keep these resolver files clean.
 func (r *Resolver) resolveCharacters(ctx context.Context, ids []model.Character) ([]model.Character, error) {
-	result := make([]model.Character, len(ids))
+	realIds := make([]string, len(ids))
 	for i, id := range ids {
-		var realId string
 		if human, ok := id.(model.Human); ok {
-			realId = human.ID
+			realIds[i] = human.ID
 		}
 		if droid, ok := id.(model.Droid); ok {
-			realId = droid.ID
+			realIds[i] = droid.ID
 		}
-		char, err := r.Query().Character(ctx, realId)
-		if err != nil {
-			return nil, err
-		}
-		result[i] = char
 	}
-	return result, nil
+	return r.loadCharacters(ctx, realIds), nil
 }
-func decodeCursor(s string) (int, error) {
-	b, err := base64.StdEncoding.DecodeString(s)
+func (r *Resolver) resolveFriendConnection(_ context.Context, ids []model.Character, args pagination.Args) (*model.FriendsConnection, error) {
+	window, err := pagination.Slice(len(ids), args)
 	if err != nil {
-		return 0, err
+		return nil, err
+	}
+
+	edges := make([]*model.FriendsEdge, 0, window.To-window.From)
+	for i := window.From; i < window.To; i++ {
+		edges = append(edges, &model.FriendsEdge{
+			Cursor: pagination.EncodeCursor(i),
+			Node:   ids[i],
+		})
+	}
+	pageInfo := &model.PageInfo{
+		HasNextPage:     window.HasNextPage,
+		HasPreviousPage: window.HasPreviousPage,
 	}
-	i, err := strconv.Atoi(strings.TrimPrefix(string(b), "cursor"))
+	if window.To > window.From {
+		start, end := pagination.EncodeCursor(window.From), pagination.EncodeCursor(window.To-1)
+		pageInfo.StartCursor, pageInfo.EndCursor = &start, &end
+	}
+
+	return &model.FriendsConnection{
+		Friends:    ids[window.From:window.To],
+		Edges:      edges,
+		TotalCount: len(ids),
+		PageInfo:   pageInfo,
+	}, nil
+}
+func (r *Resolver) resolveNode(id string) (model.Node, error) {
+	typeName, key, err := globalid.Decode(id)
 	if err != nil {
-		return 0, err
+		return nil, err
+	}
+	switch typeName {
+	case "Human":
+		if h, ok := r.humans[key]; ok {
+			return &h, nil
+		}
+	case "Droid":
+		if d, ok := r.droid[key]; ok {
+			return &d, nil
+		}
+	case "Starship":
+		if s, ok := r.starships[key]; ok {
+			return &s, nil
+		}
+	default:
+		return nil, apperr.BadUserInputf("unknown type %q in global id %q", typeName, id)
 	}
-	return i, err
+	return nil, nil
 }
-func encodeCursor(i int) string {
-	return base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf("cursor%d", i)))
+func sortReviews(reviews []*model.Review, order model.ReviewOrder) {
+	less := func(a, b *model.Review) bool {
+		if order.Field == model.ReviewOrderFieldStars {
+			return a.Stars < b.Stars
+		}
+		if a.Time == nil || b.Time == nil {
+			return a.Time == nil && b.Time != nil
+		}
+		return a.Time.Before(*b.Time)
+	}
+	sort.SliceStable(reviews, func(i, j int) bool {
+		if order.Direction == model.OrderDirectionDesc {
+			return less(reviews[j], reviews[i])
+		}
+		return less(reviews[i], reviews[j])
+	})
 }
-func (r *Resolver) resolveFriendConnection(_ context.Context, ids []model.Character, limit *int, begin *string) (*model.FriendsConnection, error) {
-	from := 0
-	if begin != nil {
-		cursor, _ := decodeCursor(*begin)
-		from = cursor
+func (r *Resolver) addReviews(episode model.Episode, inputs []*model.ReviewInput, now time.Time) []*model.Review {
+	reviews := make([]*model.Review, len(inputs))
+	r.reviewsMu.Lock()
+	for i, input := range inputs {
+		r.lastReview++
+		reviews[i] = &model.Review{
+			ID:         strconv.Itoa(r.lastReview),
+			Stars:      input.Stars,
+			Commentary: input.Commentary,
+			Time:       &now,
+		}
+		if input.Time != nil {
+			reviews[i].Time = input.Time
+		}
+		r.reviews[episode] = append(r.reviews[episode], reviews[i])
 	}
+	r.reviewsMu.Unlock()
 
-	if from >= len(ids) {
-		return &model.FriendsConnection{
-			TotalCount: len(ids),
-			PageInfo:   &model.PageInfo{HasNextPage: false},
-		}, nil
+	for _, review := range reviews {
+		r.reviewAdded.publish(episode, review)
 	}
+	return reviews
+}
 
-	to := len(ids)
-	if limit != nil {
-		to = from + *limit
-		if to > len(ids) {
-			to = len(ids)
+// findReview locates a review by ID; the caller must hold reviewsMu.
+func (r *Resolver) findReview(id string) (model.Episode, int, bool) {
+	for episode, reviews := range r.reviews {
+		for i, review := range reviews {
+			if review.ID == id {
+				return episode, i, true
+			}
 		}
 	}
-
-	return &model.FriendsConnection{
-		Friends:    ids[from:to],
-		TotalCount: len(ids),
-		PageInfo: &model.PageInfo{
-			StartCursor: encodeCursor(from + 1),
-			EndCursor:   encodeCursor(to + 1),
-			HasNextPage: to >= len(ids),
-		},
-	}, nil
+	return "", 0, false
 }
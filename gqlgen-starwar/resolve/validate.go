@@ -0,0 +1,41 @@
+package resolve
+
+import (
+	"context"
+	"graphql/apperr"
+	"graphql/gqlgen-starwar/model"
+	"time"
+	"unicode/utf8"
+
+	"github.com/99designs/gqlgen/graphql"
+)
+
+const maxCommentaryLength = 1000
+
+// validateReview checks a review input found at field of the arguments and
+// adds a BadUserInput error to the response for every problem, returning
+// false if any.
+func validateReview(ctx context.Context, field []interface{}, review *model.ReviewInput, now time.Time) bool {
+	valid := true
+	fail := func(name, format string, args ...interface{}) {
+		valid = false
+		err := apperr.BadUserInputf(format, args...)
+		err.Field = append(append([]interface{}{}, field...), name)
+		graphql.AddError(ctx, err)
+	}
+
+	if review.Stars < 0 || review.Stars > 5 {
+		fail("stars", "stars must be between 0 and 5, got %d", review.Stars)
+	}
+	if review.Commentary != nil && utf8.RuneCountInString(*review.Commentary) > maxCommentaryLength {
+		fail("commentary", "commentary must be at most %d characters", maxCommentaryLength)
+	}
+	if review.Time != nil && review.Time.After(now) {
+		fail("time", "time must not be in the future")
+	}
+	return valid
+}
+
+func reviewNotFound(id string) error {
+	return apperr.NotFoundf("review %q not found", id)
+}
@@ -0,0 +1,39 @@
+package resolve
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestValidateReview(t *testing.T) {
+	_, ts := serve(t, false)
+	body, _ := json.Marshal(map[string]string{"query": `mutation {
+		createReviews(episode: JEDI, reviews: [{stars: 5}, {stars: 6, time: "2999-01-01T00:00:00Z"}]) { stars }
+	}`})
+	resp, err := http.Post(ts.URL, "application/json", bytes.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	var result struct {
+		Errors []struct {
+			Message    string
+			Extensions map[string]interface{}
+		}
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		t.Fatal(err)
+	}
+	want := [][]interface{}{{"reviews", 1.0, "stars"}, {"reviews", 1.0, "time"}}
+	if len(result.Errors) != len(want) {
+		t.Fatalf("got errors %+v, want %d", result.Errors, len(want))
+	}
+	for i, err := range result.Errors {
+		if err.Extensions["code"] != "BAD_USER_INPUT" || !reflect.DeepEqual(err.Extensions["field"], want[i]) {
+			t.Errorf("got error %q with extensions %v, want BAD_USER_INPUT at %v", err.Message, err.Extensions, want[i])
+		}
+	}
+}
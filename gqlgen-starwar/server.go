@@ -1,24 +1,80 @@
 package main
 
 import (
+	"flag"
+	"graphql/apperr"
+	"graphql/dataset"
+	"graphql/explorer"
 	"graphql/gqlgen-starwar/generated"
+	"graphql/gqlgen-starwar/persisted"
 	"graphql/gqlgen-starwar/resolve"
+	"graphql/metrics"
+	"graphql/querylimit"
 	"log"
 	"net/http"
+	"time"
 
 	"github.com/99designs/gqlgen/graphql/handler"
-	"github.com/99designs/gqlgen/graphql/playground"
+	"github.com/99designs/gqlgen/graphql/handler/extension"
+	"github.com/99designs/gqlgen/graphql/handler/lru"
+	"github.com/99designs/gqlgen/graphql/handler/transport"
 )
 
 const defaultPort = "8080"
 
+var (
+	datasetPath  = flag.String("dataset", "", "JSON or YAML fixture of the Star Wars data, the embedded one if empty")
+	limits       = querylimit.Flags()
+	explore      = explorer.Flags("Star Wars", "/query")
+	manifestPath = flag.String("persisted-queries", "", "JSON manifest of persisted queries, keyed by their SHA-256 hash")
+	strict       = flag.Bool("strict", false, "only execute operations from the persisted query manifest")
+	apqCacheSize = flag.Int("apq-cache-size", 100, "number of automatic persisted queries to keep")
+)
+
 func main() {
+	flag.Parse()
+
+	ds, err := dataset.Open(*datasetPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var manifest persisted.Manifest
+	if *manifestPath != "" {
+		manifest, err = persisted.LoadManifest(*manifestPath)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
 
-	srv := handler.NewDefaultServer(generated.NewExecutableSchema(resolve.NewResolver()))
+	srv := handler.New(generated.NewExecutableSchema(resolve.NewResolver(ds)))
+	srv.AddTransport(transport.Websocket{
+		KeepAlivePingInterval: 10 * time.Second,
+	})
+	srv.AddTransport(transport.Options{})
+	srv.AddTransport(transport.GET{})
+	srv.AddTransport(transport.POST{})
+	srv.AddTransport(transport.MultipartForm{})
+	srv.SetErrorPresenter(apperr.ErrorPresenter)
+	srv.SetQueryCache(lru.New(1000))
+	m := metrics.New()
+	srv.Use(metrics.Extension{Metrics: m})
+	srv.Use(extension.Introspection{})
+	srv.Use(persisted.Queries{
+		Manifest: manifest,
+		Strict:   *strict,
+	})
+	if !*strict {
+		srv.Use(extension.AutomaticPersistedQuery{
+			Cache: lru.New(*apqCacheSize),
+		})
+	}
+	srv.Use(&querylimit.Extension{Config: *limits})
 
-	http.Handle("/", playground.Handler("GraphQL playground", "/query"))
-	http.Handle("/query", srv)
+	http.Handle("/", explorer.Handler(*explore))
+	http.Handle("/query", resolve.LoaderMiddleware(srv))
+	http.Handle("/metrics", m)
 
-	log.Printf("connect to http://localhost:%s/ for GraphQL playground", defaultPort)
+	log.Printf("connect to http://localhost:%s/ for the GraphQL explorer", defaultPort)
 	log.Fatal(http.ListenAndServe(":"+defaultPort, nil))
 }
@@ -0,0 +1,152 @@
+package graph
+
+import (
+	"context"
+	"graphql/apperr"
+	"graphql/gqlgen/graph/model"
+	"sync"
+
+	"github.com/99designs/gqlgen/graphql"
+)
+
+// subscriberBuffer is the number of changes a subscriber may fall behind by
+// before it is dropped.
+const subscriberBuffer = 64
+
+// changeBus hands the changes made by the mutations to the subscriptions
+// interested in them: those to the user of the changed todo and those to
+// every user. The zero value is ready to use.
+type changeBus struct {
+	// order is held while a change is made and published, so that the
+	// changes are published in the order they were made.
+	order sync.Mutex
+
+	mu sync.Mutex
+	// subscribers are keyed by the user they are interested in, "" for every
+	// user, and hold the function to call if they are dropped.
+	subscribers map[string]map[chan *model.TodoChange]func()
+}
+
+// change makes a change with do and publishes it as kind if it succeeded.
+func (b *changeBus) change(kind model.TodoChangeKind, do func() (*model.Todo, error)) (*model.Todo, error) {
+	b.order.Lock()
+	defer b.order.Unlock()
+	todo, err := do()
+	if err == nil && todo != nil {
+		b.publish(&model.TodoChange{Kind: kind, Todo: todo})
+	}
+	return todo, err
+}
+
+// publish sends change to its subscribers. A subscriber whose buffer is full
+// is dropped rather than holding up the mutations: its drop function is
+// called, then its channel closed.
+func (b *changeBus) publish(change *model.TodoChange) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, userID := range []string{change.Todo.UserID, ""} {
+		for ch, drop := range b.subscribers[userID] {
+			select {
+			case ch <- change:
+			default:
+				drop()
+				b.remove(userID, ch)
+			}
+		}
+	}
+}
+
+// subscribe returns a channel of the changes to the todos of userID, of
+// every user if empty, and a function to call once done with it. drop is
+// called before the channel is closed if the subscriber falls behind.
+func (b *changeBus) subscribe(userID string, drop func()) (<-chan *model.TodoChange, func()) {
+	ch := make(chan *model.TodoChange, subscriberBuffer)
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.subscribers == nil {
+		b.subscribers = map[string]map[chan *model.TodoChange]func(){}
+	}
+	if b.subscribers[userID] == nil {
+		b.subscribers[userID] = map[chan *model.TodoChange]func(){}
+	}
+	b.subscribers[userID][ch] = drop
+	return ch, func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		b.remove(userID, ch)
+	}
+}
+
+// remove closes ch and stops sending changes to it, if not done already.
+// The caller holds mu.
+func (b *changeBus) remove(userID string, ch chan *model.TodoChange) {
+	if _, ok := b.subscribers[userID][ch]; !ok {
+		return
+	}
+	delete(b.subscribers[userID], ch)
+	if len(b.subscribers[userID]) == 0 {
+		delete(b.subscribers, userID)
+	}
+	close(ch)
+}
+
+// SlowConsumers is a handler extension ending the subscriptions dropped for
+// falling behind with a SLOW_CONSUMER error. gqlgen sends no errors with the
+// events of a subscription, so the error is sent as a last response of the
+// operation, after its channel is closed.
+type SlowConsumers struct{}
+
+var _ interface {
+	graphql.HandlerExtension
+	graphql.OperationInterceptor
+	graphql.ResponseInterceptor
+} = SlowConsumers{}
+
+type droppedKey struct{}
+
+// dropped tells the responses of an operation that its subscription was
+// dropped.
+type dropped struct {
+	mu  sync.Mutex
+	set bool
+}
+
+func (SlowConsumers) ExtensionName() string {
+	return "SlowConsumers"
+}
+
+func (SlowConsumers) Validate(schema graphql.ExecutableSchema) error {
+	return nil
+}
+
+func (SlowConsumers) InterceptOperation(ctx context.Context, next graphql.OperationHandler) graphql.ResponseHandler {
+	return next(context.WithValue(ctx, droppedKey{}, &dropped{}))
+}
+
+func (SlowConsumers) InterceptResponse(ctx context.Context, next graphql.ResponseHandler) *graphql.Response {
+	resp := next(ctx)
+	d, ok := ctx.Value(droppedKey{}).(*dropped)
+	if resp != nil || !ok {
+		return resp
+	}
+	d.mu.Lock()
+	set := d.set
+	d.set = false
+	d.mu.Unlock()
+	if !set {
+		return nil
+	}
+	graphql.AddError(ctx, &apperr.Error{Code: apperr.SlowConsumer, Message: "the subscription fell behind its events and was ended"})
+	return &graphql.Response{Errors: graphql.GetErrors(ctx)}
+}
+
+// markDropped records in ctx, that of a subscription resolver, that the
+// subscription was dropped, for SlowConsumers to send the error. It does
+// nothing if the handler does not use SlowConsumers.
+func markDropped(ctx context.Context) {
+	if d, ok := ctx.Value(droppedKey{}).(*dropped); ok {
+		d.mu.Lock()
+		d.set = true
+		d.mu.Unlock()
+	}
+}
@@ -0,0 +1,26 @@
+package model
+
+import "time"
+
+// Todo is a todo of a user, whose user is resolved from UserID so that it
+// is always the current one.
+type Todo struct {
+	ID         string      `json:"id"`
+	Text       string      `json:"text"`
+	Done       bool        `json:"done"`
+	UserID     string      `json:"userId"`
+	DueAt      *time.Time  `json:"dueAt,omitempty"`
+	Priority   Priority    `json:"priority"`
+	Tags       []string    `json:"tags"`
+	Recurrence *Recurrence `json:"recurrence,omitempty"`
+	// RecurrenceDay is the day of the month a monthly todo is due on, which
+	// its occurrences keep even when a shorter month moves one to its last
+	// day. It is 0 for other todos, or for the day of DueAt.
+	RecurrenceDay int `json:"recurrenceDay,omitempty"`
+}
+
+// User owns todos, resolved by the todos field.
+type User struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
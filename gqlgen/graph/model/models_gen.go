@@ -2,19 +2,295 @@
 
 package model
 
-type NewTodo struct {
-	Text   string `json:"text"`
-	UserID string `json:"userId"`
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+type CreateUserInput struct {
+	Name string `json:"name"`
 }
 
-type Todo struct {
-	ID   string `json:"id"`
+type NewTodo struct {
 	Text string `json:"text"`
-	Done bool   `json:"done"`
-	User *User  `json:"user"`
+	// The ID of an existing user.
+	UserID string     `json:"userId"`
+	DueAt  *time.Time `json:"dueAt"`
+	// MEDIUM if not given.
+	Priority *Priority `json:"priority"`
+	Tags     []string  `json:"tags"`
+	// Requires dueAt, from which the next occurrences are due.
+	Recurrence *Recurrence `json:"recurrence"`
 }
 
-type User struct {
-	ID   string `json:"id"`
-	Name string `json:"name"`
+type PageInfo struct {
+	StartCursor     *string `json:"startCursor"`
+	EndCursor       *string `json:"endCursor"`
+	HasNextPage     bool    `json:"hasNextPage"`
+	HasPreviousPage bool    `json:"hasPreviousPage"`
+}
+
+type TodoChange struct {
+	Kind TodoChangeKind `json:"kind"`
+	// The todo as it is after the change, or was before it if deleted.
+	Todo *Todo `json:"todo"`
+}
+
+// A page of todos.
+type TodoConnection struct {
+	// The number of todos matching the filter, across all pages.
+	TotalCount int         `json:"totalCount"`
+	Edges      []*TodoEdge `json:"edges"`
+	PageInfo   *PageInfo   `json:"pageInfo"`
+}
+
+type TodoEdge struct {
+	// Points at the todo, and remains valid when todos are created or deleted.
+	Cursor string `json:"cursor"`
+	Node   *Todo  `json:"node"`
+}
+
+// Todos match a filter if they match all of its fields that are set.
+type TodoFilterInput struct {
+	Done   *bool   `json:"done"`
+	UserID *string `json:"userId"`
+	// Matches todos whose text contains this string, ignoring case.
+	TextContains *string `json:"textContains"`
+}
+
+type TodoOrderInput struct {
+	Field TodoOrderField `json:"field"`
+	// The direction to order in, ascending by default.
+	Direction OrderDirection `json:"direction"`
+}
+
+// Changes to a todo, fields left out are kept. dueAt and recurrence are removed if set to null.
+type UpdateTodoInput struct {
+	Text *string `json:"text"`
+	// Completing a recurring todo creates its next occurrence.
+	Done     *bool      `json:"done"`
+	DueAt    *time.Time `json:"dueAt"`
+	Priority *Priority  `json:"priority"`
+	// Replaces the tags of the todo.
+	Tags       []string    `json:"tags"`
+	Recurrence *Recurrence `json:"recurrence"`
+}
+
+type OrderDirection string
+
+const (
+	OrderDirectionAsc  OrderDirection = "ASC"
+	OrderDirectionDesc OrderDirection = "DESC"
+)
+
+var AllOrderDirection = []OrderDirection{
+	OrderDirectionAsc,
+	OrderDirectionDesc,
+}
+
+func (e OrderDirection) IsValid() bool {
+	switch e {
+	case OrderDirectionAsc, OrderDirectionDesc:
+		return true
+	}
+	return false
+}
+
+func (e OrderDirection) String() string {
+	return string(e)
+}
+
+func (e *OrderDirection) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = OrderDirection(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid OrderDirection", str)
+	}
+	return nil
+}
+
+func (e OrderDirection) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type Priority string
+
+const (
+	PriorityLow    Priority = "LOW"
+	PriorityMedium Priority = "MEDIUM"
+	PriorityHigh   Priority = "HIGH"
+)
+
+var AllPriority = []Priority{
+	PriorityLow,
+	PriorityMedium,
+	PriorityHigh,
+}
+
+func (e Priority) IsValid() bool {
+	switch e {
+	case PriorityLow, PriorityMedium, PriorityHigh:
+		return true
+	}
+	return false
+}
+
+func (e Priority) String() string {
+	return string(e)
+}
+
+func (e *Priority) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = Priority(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid Priority", str)
+	}
+	return nil
+}
+
+func (e Priority) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type Recurrence string
+
+const (
+	RecurrenceDaily  Recurrence = "DAILY"
+	RecurrenceWeekly Recurrence = "WEEKLY"
+	// On the same day of the month, or its last day if the month is shorter.
+	RecurrenceMonthly Recurrence = "MONTHLY"
+)
+
+var AllRecurrence = []Recurrence{
+	RecurrenceDaily,
+	RecurrenceWeekly,
+	RecurrenceMonthly,
+}
+
+func (e Recurrence) IsValid() bool {
+	switch e {
+	case RecurrenceDaily, RecurrenceWeekly, RecurrenceMonthly:
+		return true
+	}
+	return false
+}
+
+func (e Recurrence) String() string {
+	return string(e)
+}
+
+func (e *Recurrence) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = Recurrence(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid Recurrence", str)
+	}
+	return nil
+}
+
+func (e Recurrence) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type TodoChangeKind string
+
+const (
+	TodoChangeKindCreated TodoChangeKind = "CREATED"
+	TodoChangeKindUpdated TodoChangeKind = "UPDATED"
+	TodoChangeKindDeleted TodoChangeKind = "DELETED"
+)
+
+var AllTodoChangeKind = []TodoChangeKind{
+	TodoChangeKindCreated,
+	TodoChangeKindUpdated,
+	TodoChangeKindDeleted,
+}
+
+func (e TodoChangeKind) IsValid() bool {
+	switch e {
+	case TodoChangeKindCreated, TodoChangeKindUpdated, TodoChangeKindDeleted:
+		return true
+	}
+	return false
+}
+
+func (e TodoChangeKind) String() string {
+	return string(e)
+}
+
+func (e *TodoChangeKind) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = TodoChangeKind(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid TodoChangeKind", str)
+	}
+	return nil
+}
+
+func (e TodoChangeKind) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type TodoOrderField string
+
+const (
+	// The order the todos were created in.
+	TodoOrderFieldCreated TodoOrderField = "CREATED"
+	// Alphabetical order, ignoring case.
+	TodoOrderFieldText TodoOrderField = "TEXT"
+	// Todos not done first, in ascending order.
+	TodoOrderFieldDone TodoOrderField = "DONE"
+)
+
+var AllTodoOrderField = []TodoOrderField{
+	TodoOrderFieldCreated,
+	TodoOrderFieldText,
+	TodoOrderFieldDone,
+}
+
+func (e TodoOrderField) IsValid() bool {
+	switch e {
+	case TodoOrderFieldCreated, TodoOrderFieldText, TodoOrderFieldDone:
+		return true
+	}
+	return false
+}
+
+func (e TodoOrderField) String() string {
+	return string(e)
+}
+
+func (e *TodoOrderField) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = TodoOrderField(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid TodoOrderField", str)
+	}
+	return nil
+}
+
+func (e TodoOrderField) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
 }
@@ -1,8 +1,73 @@
 package graph
 
+import (
+	"graphql/apperr"
+	"graphql/gqlgen/graph/model"
+	"graphql/gqlgen/store"
+	"strings"
+)
+
 // This file will not be regenerated automatically.
 //
 // It serves as dependency injection for your app, add any dependencies you require here.
 
 type Resolver struct {
+	Repository store.TodoRepository
+
+	changes changeBus
+}
+
+// updateTodo applies update to a copy of the todo id and saves it if valid,
+// or returns nil if there is none. Completing a recurring todo creates its
+// next occurrence, to which the recurrence moves. The next occurrence is
+// created first, and deleted again if the todo cannot be saved, so that a
+// failure never leaves a completed todo without it.
+func (r *Resolver) updateTodo(id string, update func(todo *model.Todo)) (*model.Todo, error) {
+	r.changes.order.Lock()
+	defer r.changes.order.Unlock()
+	todo, err := r.Repository.Todo(id)
+	if err != nil || todo == nil {
+		return todo, err
+	}
+	updated := *todo
+	update(&updated)
+	if err := checkRecurrence(&updated); err != nil {
+		return nil, err
+	}
+	var next *model.Todo
+	if !todo.Done && updated.Done && updated.Recurrence != nil {
+		if next, err = r.Repository.CreateTodo(*nextOccurrence(&updated)); err != nil {
+			return nil, err
+		}
+		updated.Recurrence, updated.RecurrenceDay = nil, 0
+	}
+
+	saved, err := r.Repository.UpdateTodo(id, func(todo *model.Todo) { *todo = updated })
+	if err != nil || saved == nil {
+		if next != nil {
+			r.Repository.DeleteTodo(next.ID)
+		}
+		return saved, err
+	}
+	if next != nil {
+		r.changes.publish(&model.TodoChange{Kind: model.TodoChangeKindCreated, Todo: next})
+	}
+	r.changes.publish(&model.TodoChange{Kind: model.TodoChangeKindUpdated, Todo: saved})
+	return saved, nil
+}
+
+func todoNotFound(id string) error {
+	return apperr.NotFoundf("todo %q not found", id)
+}
+
+func userNotFound(id string) error {
+	return apperr.NotFoundf("user %q not found", id)
+}
+
+// checkText returns an error if text is blank.
+func checkText(field, text string) error {
+	if strings.TrimSpace(text) == "" {
+		return apperr.BadUserInputf("%s must not be blank", field)
+	}
+	return nil
 }
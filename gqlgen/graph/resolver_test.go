@@ -0,0 +1,94 @@
+package graph
+
+import (
+	"context"
+	"errors"
+	"graphql/gqlgen/graph/model"
+	"graphql/gqlgen/store"
+	"testing"
+	"time"
+)
+
+// newRecurring returns a resolver with a todo due on the 31st of January,
+// recurring monthly.
+func newRecurring(t *testing.T, repository store.TodoRepository) (*Resolver, *model.Todo) {
+	t.Helper()
+	r := &Resolver{Repository: repository}
+	user, err := repository.CreateUser("Leia")
+	if err != nil {
+		t.Fatal(err)
+	}
+	dueAt := time.Date(2021, 1, 31, 9, 0, 0, 0, time.UTC)
+	monthly := model.RecurrenceMonthly
+	todo, err := r.Mutation().CreateTodo(context.Background(), model.NewTodo{Text: "Report to Mon Mothma", UserID: user.ID, DueAt: &dueAt, Recurrence: &monthly})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return r, todo
+}
+
+func TestToggleRecurring(t *testing.T) {
+	r, todo := newRecurring(t, store.NewMemoryTodoRepository())
+	changes, done := r.changes.subscribe("", func() {})
+	defer done()
+
+	for _, want := range []time.Time{
+		time.Date(2021, 2, 28, 9, 0, 0, 0, time.UTC),
+		time.Date(2021, 3, 31, 9, 0, 0, 0, time.UTC),
+	} {
+		completed, err := r.Mutation().ToggleTodo(context.Background(), todo.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !completed.Done || completed.Recurrence != nil {
+			t.Errorf("got completed todo done %v, recurrence %v, want done without recurrence", completed.Done, completed.Recurrence)
+		}
+		created, updated := <-changes, <-changes
+		if created.Kind != model.TodoChangeKindCreated || updated.Kind != model.TodoChangeKindUpdated || updated.Todo.ID != todo.ID {
+			t.Fatalf("got changes %s %s then %s %s, want the next occurrence created then %s updated", created.Kind, created.Todo.ID, updated.Kind, updated.Todo.ID, todo.ID)
+		}
+		next := created.Todo
+		if next.Done || next.Recurrence == nil || *next.Recurrence != model.RecurrenceMonthly || !next.DueAt.Equal(want) {
+			t.Errorf("got next occurrence done %v, recurrence %v, due %v, want not done, monthly, due %v", next.Done, next.Recurrence, next.DueAt, want)
+		}
+		todo = next
+	}
+
+	// Toggling a completed occurrence back does not create another.
+	if _, err := r.Mutation().ToggleTodo(context.Background(), "T1"); err != nil {
+		t.Fatal(err)
+	}
+	if todos, _ := r.Repository.Todos(); len(todos) != 3 {
+		t.Errorf("got %d todos, want 3", len(todos))
+	}
+}
+
+// failingUpdates is a repository whose todos cannot be updated.
+type failingUpdates struct {
+	store.TodoRepository
+}
+
+var errUpdate = errors.New("disk full")
+
+func (failingUpdates) UpdateTodo(string, func(*model.Todo)) (*model.Todo, error) {
+	return nil, errUpdate
+}
+
+func TestToggleRecurringFailedSave(t *testing.T) {
+	r, todo := newRecurring(t, failingUpdates{store.NewMemoryTodoRepository()})
+	changes, done := r.changes.subscribe("", func() {})
+	defer done()
+
+	if _, err := r.Mutation().ToggleTodo(context.Background(), todo.ID); !errors.Is(err, errUpdate) {
+		t.Fatalf("got error %v, want %v", err, errUpdate)
+	}
+	todos, _ := r.Repository.Todos()
+	if len(todos) != 1 || todos[0].Done || todos[0].Recurrence == nil {
+		t.Errorf("got todos %+v, want the recurring todo alone and not done", todos)
+	}
+	select {
+	case change := <-changes:
+		t.Errorf("got change %s %s, want none", change.Kind, change.Todo.ID)
+	default:
+	}
+}
@@ -0,0 +1,123 @@
+package graph
+
+import (
+	"context"
+	"graphql/apperr"
+	"graphql/gqlgen/graph/model"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql"
+)
+
+// checkTags returns tags trimmed and without duplicates, never nil, or an
+// error if one of them is blank.
+func checkTags(tags []string) ([]string, error) {
+	checked := make([]string, 0, len(tags))
+	seen := map[string]bool{}
+	for _, tag := range tags {
+		tag = strings.TrimSpace(tag)
+		if tag == "" {
+			return nil, apperr.BadUserInputf("tags must not be blank")
+		}
+		if !seen[tag] {
+			seen[tag] = true
+			checked = append(checked, tag)
+		}
+	}
+	return checked, nil
+}
+
+// checkRecurrence returns an error if todo recurs without a due time to
+// schedule the next occurrences from.
+func checkRecurrence(todo *model.Todo) error {
+	if todo.Recurrence != nil && todo.DueAt == nil {
+		return apperr.BadUserInputf("a recurring todo must have a dueAt")
+	}
+	return nil
+}
+
+// anchor sets the day of the month todo recurs on to that of its due time
+// if it recurs monthly, and clears it otherwise. It is called when the due
+// time or the recurrence of todo is set.
+func anchor(todo *model.Todo) {
+	todo.RecurrenceDay = 0
+	if todo.Recurrence != nil && *todo.Recurrence == model.RecurrenceMonthly && todo.DueAt != nil {
+		todo.RecurrenceDay = todo.DueAt.Day()
+	}
+}
+
+// nextOccurrence returns the todo to create once todo, a recurring one, is
+// completed: the same todo not done, due one period later.
+func nextOccurrence(todo *model.Todo) *model.Todo {
+	next := *todo
+	next.ID, next.Done = "", false
+	dueAt := nextDue(*todo.DueAt, *todo.Recurrence, todo.RecurrenceDay)
+	next.DueAt = &dueAt
+	return &next
+}
+
+// nextDue returns the time one period of recurrence after t. A month later
+// is day of the next month, the day of t if 0, or its last day if it is
+// shorter, so that a todo due on the 31st is due on the 28th of February
+// then on the 31st of March again.
+func nextDue(t time.Time, recurrence model.Recurrence, day int) time.Time {
+	switch recurrence {
+	case model.RecurrenceDaily:
+		return t.AddDate(0, 0, 1)
+	case model.RecurrenceWeekly:
+		return t.AddDate(0, 0, 7)
+	}
+	year, month, _ := t.Date()
+	if day == 0 {
+		day = t.Day()
+	}
+	first := time.Date(year, month+1, 1, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+	if last := first.AddDate(0, 1, -1).Day(); day > last {
+		day = last
+	}
+	return first.AddDate(0, 0, day-1)
+}
+
+// dueBetween returns the todos not done of the user userID, of every user
+// if nil, due from start to before end, the earliest due first.
+func dueBetween(todos []*model.Todo, userID *string, start, end time.Time) []*model.Todo {
+	due := []*model.Todo{}
+	for _, todo := range todos {
+		if todo.Done || todo.DueAt == nil || userID != nil && todo.UserID != *userID {
+			continue
+		}
+		if !todo.DueAt.Before(start) && todo.DueAt.Before(end) {
+			due = append(due, todo)
+		}
+	}
+	sort.SliceStable(due, func(i, j int) bool {
+		return due[i].DueAt.Before(*due[j].DueAt)
+	})
+	return due
+}
+
+// today returns the start of the current day in the time zone named
+// timeZone, UTC if nil, and the start of the next one.
+func today(timeZone *string) (time.Time, time.Time, error) {
+	loc := time.UTC
+	if timeZone != nil {
+		var err error
+		if loc, err = time.LoadLocation(*timeZone); err != nil {
+			return time.Time{}, time.Time{}, apperr.BadUserInputf("unknown time zone %q", *timeZone)
+		}
+	}
+	year, month, day := time.Now().In(loc).Date()
+	start := time.Date(year, month, day, 0, 0, 0, 0, loc)
+	return start, start.AddDate(0, 0, 1), nil
+}
+
+// inputFields returns the fields given for the input object argument arg of
+// the field being resolved, including those set to null, which gqlgen
+// leaves nil like those left out.
+func inputFields(ctx context.Context, arg string) map[string]interface{} {
+	field := graphql.GetFieldContext(ctx).Field
+	fields, _ := field.ArgumentMap(graphql.GetOperationContext(ctx).Variables)[arg].(map[string]interface{})
+	return fields
+}
@@ -0,0 +1,108 @@
+package graph
+
+import (
+	"context"
+	"graphql/apperr"
+	"graphql/gqlgen/graph/model"
+	"graphql/gqlgen/store"
+	"testing"
+	"time"
+)
+
+func TestNextDue(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+	date := func(year int, month time.Month, day, hour int, loc *time.Location) time.Time {
+		return time.Date(year, month, day, hour, 30, 0, 0, loc)
+	}
+	tests := []struct {
+		name       string
+		t          time.Time
+		recurrence model.Recurrence
+		day        int
+		want       time.Time
+	}{
+		{"daily", date(2021, 12, 31, 9, time.UTC), model.RecurrenceDaily, 0, date(2022, 1, 1, 9, time.UTC)},
+		{"daily across a DST change", date(2021, 3, 13, 9, newYork), model.RecurrenceDaily, 0, date(2021, 3, 14, 9, newYork)},
+		{"weekly", date(2021, 2, 25, 9, time.UTC), model.RecurrenceWeekly, 0, date(2021, 3, 4, 9, time.UTC)},
+		{"monthly", date(2021, 1, 15, 9, time.UTC), model.RecurrenceMonthly, 15, date(2021, 2, 15, 9, time.UTC)},
+		{"monthly from the 31st to February", date(2021, 1, 31, 9, time.UTC), model.RecurrenceMonthly, 31, date(2021, 2, 28, 9, time.UTC)},
+		{"monthly from February back to the 31st", date(2021, 2, 28, 9, time.UTC), model.RecurrenceMonthly, 31, date(2021, 3, 31, 9, time.UTC)},
+		{"monthly to February of a leap year", date(2024, 1, 31, 9, time.UTC), model.RecurrenceMonthly, 31, date(2024, 2, 29, 9, time.UTC)},
+		{"monthly to a 30 day month", date(2021, 3, 31, 9, time.UTC), model.RecurrenceMonthly, 31, date(2021, 4, 30, 9, time.UTC)},
+		{"monthly across the year", date(2021, 12, 31, 9, time.UTC), model.RecurrenceMonthly, 31, date(2022, 1, 31, 9, time.UTC)},
+		{"monthly without a day", date(2021, 1, 30, 9, time.UTC), model.RecurrenceMonthly, 0, date(2021, 2, 28, 9, time.UTC)},
+		{"monthly across a DST change", date(2021, 10, 31, 9, newYork), model.RecurrenceMonthly, 31, date(2021, 11, 30, 9, newYork)},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := nextDue(test.t, test.recurrence, test.day); !got.Equal(test.want) {
+				t.Errorf("nextDue(%v, %s, %d) = %v, want %v", test.t, test.recurrence, test.day, got, test.want)
+			}
+		})
+	}
+}
+
+func TestTodosDueToday(t *testing.T) {
+	r := &Resolver{Repository: store.NewMemoryTodoRepository()}
+	user, err := r.Repository.CreateUser("Leia")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Kiritimati is 25 hours ahead of Pago Pago, so that their current days
+	// never overlap.
+	zones := []string{"UTC", "Pacific/Kiritimati", "Pacific/Pago_Pago"}
+	todos := map[string]map[string]bool{}
+	for _, zone := range zones {
+		loc, err := time.LoadLocation(zone)
+		if err != nil {
+			t.Fatal(err)
+		}
+		year, month, day := time.Now().In(loc).Date()
+		start := time.Date(year, month, day, 0, 0, 0, 0, loc)
+		todos[zone] = map[string]bool{}
+		for _, due := range []struct {
+			t     time.Time
+			today bool
+		}{
+			{start.Add(-time.Minute), false},
+			{start, true},
+			{start.AddDate(0, 0, 1).Add(-time.Minute), true},
+			{start.AddDate(0, 0, 1), false},
+		} {
+			dueAt := due.t
+			todo, err := r.Repository.CreateTodo(model.Todo{Text: zone, UserID: user.ID, DueAt: &dueAt})
+			if err != nil {
+				t.Fatal(err)
+			}
+			todos[zone][todo.ID] = due.today
+		}
+	}
+
+	for _, zone := range zones {
+		t.Run(zone, func(t *testing.T) {
+			timeZone := zone
+			due, err := r.Query().TodosDueToday(context.Background(), nil, &timeZone)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := map[string]bool{}
+			for _, todo := range due {
+				got[todo.ID] = true
+			}
+			for id, today := range todos[zone] {
+				if got[id] != today {
+					t.Errorf("todo %s due today: got %v, want %v", id, got[id], today)
+				}
+			}
+		})
+	}
+
+	unknown := "Mars/Olympus_Mons"
+	_, err = r.Query().TodosDueToday(context.Background(), nil, &unknown)
+	if apperr.CodeOf(err) != apperr.BadUserInput {
+		t.Errorf("got error %v for an unknown time zone, want %s", err, apperr.BadUserInput)
+	}
+}
@@ -5,25 +5,205 @@ package graph
 
 import (
 	"context"
-	"fmt"
+	"errors"
+	"graphql/apperr"
 	"graphql/gqlgen/graph/generated"
 	"graphql/gqlgen/graph/model"
-	"math/rand"
+	"graphql/gqlgen/store"
+	"time"
 )
 
-var todos []*model.Todo
-
 func (r *mutationResolver) CreateTodo(ctx context.Context, input model.NewTodo) (*model.Todo, error) {
-	todo := &model.Todo{
-		Text: input.Text,
-		ID:   fmt.Sprintf("T%d", rand.Int()),
-		User: &model.User{ID: input.UserID, Name: "user " + input.UserID},
+	if err := checkText("text", input.Text); err != nil {
+		return nil, err
+	}
+	tags, err := checkTags(input.Tags)
+	if err != nil {
+		return nil, err
+	}
+	todo := model.Todo{
+		Text:       input.Text,
+		UserID:     input.UserID,
+		DueAt:      input.DueAt,
+		Priority:   model.PriorityMedium,
+		Tags:       tags,
+		Recurrence: input.Recurrence,
+	}
+	if input.Priority != nil {
+		todo.Priority = *input.Priority
+	}
+	if err := checkRecurrence(&todo); err != nil {
+		return nil, err
+	}
+	anchor(&todo)
+	created, err := r.changes.change(model.TodoChangeKindCreated, func() (*model.Todo, error) {
+		return r.Repository.CreateTodo(todo)
+	})
+	if errors.Is(err, store.ErrUnknownUser) {
+		return nil, apperr.BadUserInputf("user %q does not exist", input.UserID)
+	}
+	return created, err
+}
+
+func (r *mutationResolver) UpdateTodo(ctx context.Context, id string, input model.UpdateTodoInput) (*model.Todo, error) {
+	if input.Text != nil {
+		if err := checkText("text", *input.Text); err != nil {
+			return nil, err
+		}
+	}
+	var tags []string
+	if input.Tags != nil {
+		var err error
+		if tags, err = checkTags(input.Tags); err != nil {
+			return nil, err
+		}
+	}
+	given := inputFields(ctx, "input")
+	_, dueAtGiven := given["dueAt"]
+	_, recurrenceGiven := given["recurrence"]
+	todo, err := r.updateTodo(id, func(todo *model.Todo) {
+		if input.Text != nil {
+			todo.Text = *input.Text
+		}
+		if input.Done != nil {
+			todo.Done = *input.Done
+		}
+		if dueAtGiven {
+			todo.DueAt = input.DueAt
+		}
+		if input.Priority != nil {
+			todo.Priority = *input.Priority
+		}
+		if tags != nil {
+			todo.Tags = tags
+		}
+		if recurrenceGiven {
+			todo.Recurrence = input.Recurrence
+		}
+		if dueAtGiven || recurrenceGiven {
+			anchor(todo)
+		}
+	})
+	if err == nil && todo == nil {
+		return nil, todoNotFound(id)
+	}
+	return todo, err
+}
+
+func (r *mutationResolver) ToggleTodo(ctx context.Context, id string) (*model.Todo, error) {
+	todo, err := r.updateTodo(id, func(todo *model.Todo) {
+		todo.Done = !todo.Done
+	})
+	if err == nil && todo == nil {
+		return nil, todoNotFound(id)
+	}
+	return todo, err
+}
+
+func (r *mutationResolver) DeleteTodo(ctx context.Context, id string) (*model.Todo, error) {
+	todo, err := r.changes.change(model.TodoChangeKindDeleted, func() (*model.Todo, error) {
+		return r.Repository.DeleteTodo(id)
+	})
+	if err == nil && todo == nil {
+		return nil, todoNotFound(id)
+	}
+	return todo, err
+}
+
+func (r *mutationResolver) CreateUser(ctx context.Context, input model.CreateUserInput) (*model.User, error) {
+	if err := checkText("name", input.Name); err != nil {
+		return nil, err
+	}
+	return r.Repository.CreateUser(input.Name)
+}
+
+func (r *queryResolver) Todos(ctx context.Context, filter *model.TodoFilterInput, orderBy *model.TodoOrderInput, first *int, after *string) (*model.TodoConnection, error) {
+	todos, err := r.Repository.Todos()
+	if err != nil {
+		return nil, err
+	}
+	return todoConnection(todos, filter, orderBy, first, after)
+}
+
+func (r *queryResolver) Todo(ctx context.Context, id string) (*model.Todo, error) {
+	todo, err := r.Repository.Todo(id)
+	if err == nil && todo == nil {
+		return nil, todoNotFound(id)
 	}
-	todos = append(todos, todo)
-	return todo, nil
+	return todo, err
 }
 
-func (r *queryResolver) Todos(ctx context.Context) ([]*model.Todo, error) {
+func (r *queryResolver) Users(ctx context.Context) ([]*model.User, error) {
+	return r.Repository.Users()
+}
+
+func (r *queryResolver) User(ctx context.Context, id string) (*model.User, error) {
+	user, err := r.Repository.User(id)
+	if err == nil && user == nil {
+		return nil, userNotFound(id)
+	}
+	return user, err
+}
+
+func (r *queryResolver) OverdueTodos(ctx context.Context, userID *string) ([]*model.Todo, error) {
+	todos, err := r.Repository.Todos()
+	if err != nil {
+		return nil, err
+	}
+	return dueBetween(todos, userID, time.Time{}, time.Now()), nil
+}
+
+func (r *queryResolver) TodosDueToday(ctx context.Context, userID *string, timeZone *string) ([]*model.Todo, error) {
+	start, end, err := today(timeZone)
+	if err != nil {
+		return nil, err
+	}
+	todos, err := r.Repository.Todos()
+	if err != nil {
+		return nil, err
+	}
+	return dueBetween(todos, userID, start, end), nil
+}
+
+func (r *subscriptionResolver) TodoChanged(ctx context.Context, userID *string) (<-chan *model.TodoChange, error) {
+	var id string
+	if userID != nil {
+		user, err := r.Repository.User(*userID)
+		if err != nil {
+			return nil, err
+		}
+		if user == nil {
+			return nil, apperr.BadUserInputf("user %q does not exist", *userID)
+		}
+		id = *userID
+	}
+	changes, unsubscribe := r.changes.subscribe(id, func() { markDropped(ctx) })
+	go func() {
+		<-ctx.Done()
+		unsubscribe()
+	}()
+	return changes, nil
+}
+
+func (r *todoResolver) User(ctx context.Context, obj *model.Todo) (*model.User, error) {
+	user, err := r.Repository.User(obj.UserID)
+	if err == nil && user == nil {
+		return nil, userNotFound(obj.UserID)
+	}
+	return user, err
+}
+
+func (r *userResolver) Todos(ctx context.Context, obj *model.User) ([]*model.Todo, error) {
+	all, err := r.Repository.Todos()
+	if err != nil {
+		return nil, err
+	}
+	todos := []*model.Todo{}
+	for _, todo := range all {
+		if todo.UserID == obj.ID {
+			todos = append(todos, todo)
+		}
+	}
 	return todos, nil
 }
 
@@ -37,5 +217,23 @@ func (r *Resolver) Query() generated.QueryResolver {
 	return &queryResolver{r}
 }
 
+// Subscription returns generated.SubscriptionResolver implementation.
+func (r *Resolver) Subscription() generated.SubscriptionResolver {
+	return &subscriptionResolver{r}
+}
+
+// Todo returns generated.TodoResolver implementation.
+func (r *Resolver) Todo() generated.TodoResolver {
+	return &todoResolver{r}
+}
+
+// User returns generated.UserResolver implementation.
+func (r *Resolver) User() generated.UserResolver {
+	return &userResolver{r}
+}
+
 type mutationResolver struct{ *Resolver }
 type queryResolver struct{ *Resolver }
+type subscriptionResolver struct{ *Resolver }
+type todoResolver struct{ *Resolver }
+type userResolver struct{ *Resolver }
@@ -0,0 +1,146 @@
+package graph
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"graphql/apperr"
+	"graphql/gqlgen/graph/model"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// todoKey is the position of a todo in an order. Todos are compared on the
+// value of the order field, then on their sequence, the order they were
+// created in, so that no two todos are equal.
+type todoKey struct {
+	Field model.TodoOrderField `json:"field"`
+	Value string               `json:"value,omitempty"`
+	Seq   int                  `json:"seq"`
+}
+
+// keyOf returns the key of todo in the order of field.
+func keyOf(todo *model.Todo, field model.TodoOrderField) todoKey {
+	key := todoKey{Field: field, Seq: todoSeq(todo.ID)}
+	switch field {
+	case model.TodoOrderFieldText:
+		key.Value = strings.ToLower(todo.Text)
+	case model.TodoOrderFieldDone:
+		key.Value = strconv.FormatBool(todo.Done)
+	}
+	return key
+}
+
+// todoSeq returns the sequence of a todo from its ID, T followed by the
+// sequence.
+func todoSeq(id string) int {
+	seq, _ := strconv.Atoi(strings.TrimPrefix(id, "T"))
+	return seq
+}
+
+// compare returns -1, 0 or 1 as a is before, at or after b in ascending
+// order. Both keys are of the same field; false is before true.
+func (a todoKey) compare(b todoKey) int {
+	if c := strings.Compare(a.Value, b.Value); c != 0 {
+		return c
+	}
+	switch {
+	case a.Seq < b.Seq:
+		return -1
+	case a.Seq > b.Seq:
+		return 1
+	}
+	return 0
+}
+
+// A cursor is a todoKey rather than an index, so that it points between the
+// same todos whatever is created or deleted.
+func encodeTodoCursor(key todoKey) string {
+	b, _ := json.Marshal(key)
+	return base64.StdEncoding.EncodeToString(b)
+}
+
+func decodeTodoCursor(s string, field model.TodoOrderField) (todoKey, error) {
+	var key todoKey
+	b, err := base64.StdEncoding.DecodeString(s)
+	if err != nil || json.Unmarshal(b, &key) != nil || !key.Field.IsValid() {
+		return key, apperr.BadUserInputf("invalid cursor %q", s)
+	}
+	if key.Field != field {
+		return key, apperr.BadUserInputf("cursor %q is for todos ordered by %s, not %s", s, key.Field, field)
+	}
+	return key, nil
+}
+
+// matches reports whether todo matches filter.
+func matches(todo *model.Todo, filter *model.TodoFilterInput) bool {
+	if filter == nil {
+		return true
+	}
+	if filter.Done != nil && todo.Done != *filter.Done {
+		return false
+	}
+	if filter.UserID != nil && todo.UserID != *filter.UserID {
+		return false
+	}
+	if filter.TextContains != nil && !strings.Contains(strings.ToLower(todo.Text), strings.ToLower(*filter.TextContains)) {
+		return false
+	}
+	return true
+}
+
+// todoConnection returns the page of todos selected by the arguments of
+// Query.todos.
+func todoConnection(todos []*model.Todo, filter *model.TodoFilterInput, orderBy *model.TodoOrderInput, first *int, after *string) (*model.TodoConnection, error) {
+	if first != nil && *first < 0 {
+		return nil, apperr.BadUserInputf("first must not be negative, got %d", *first)
+	}
+	order := model.TodoOrderInput{Field: model.TodoOrderFieldCreated, Direction: model.OrderDirectionAsc}
+	if orderBy != nil {
+		order = *orderBy
+	}
+	sign := 1
+	if order.Direction == model.OrderDirectionDesc {
+		sign = -1
+	}
+
+	type entry struct {
+		todo *model.Todo
+		key  todoKey
+	}
+	var entries []entry
+	for _, todo := range todos {
+		if matches(todo, filter) {
+			entries = append(entries, entry{todo, keyOf(todo, order.Field)})
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return sign*entries[i].key.compare(entries[j].key) < 0
+	})
+
+	conn := &model.TodoConnection{TotalCount: len(entries), Edges: []*model.TodoEdge{}, PageInfo: &model.PageInfo{}}
+	from := 0
+	if after != nil {
+		key, err := decodeTodoCursor(*after, order.Field)
+		if err != nil {
+			return nil, err
+		}
+		from = sort.Search(len(entries), func(i int) bool {
+			return sign*entries[i].key.compare(key) > 0
+		})
+	}
+	to := len(entries)
+	if first != nil && to-from > *first {
+		to = from + *first
+	}
+	for _, e := range entries[from:to] {
+		conn.Edges = append(conn.Edges, &model.TodoEdge{Cursor: encodeTodoCursor(e.key), Node: e.todo})
+	}
+	if len(conn.Edges) > 0 {
+		conn.PageInfo.StartCursor = &conn.Edges[0].Cursor
+		conn.PageInfo.EndCursor = &conn.Edges[len(conn.Edges)-1].Cursor
+	}
+	conn.PageInfo.HasPreviousPage = from > 0
+	conn.PageInfo.HasNextPage = to < len(entries)
+	return conn, nil
+}
@@ -1,29 +1,69 @@
 package main
 
 import (
+	"flag"
+	"graphql/apperr"
+	"graphql/explorer"
 	"graphql/gqlgen/graph"
 	"graphql/gqlgen/graph/generated"
+	"graphql/gqlgen/sse"
+	"graphql/gqlgen/store"
+	"graphql/metrics"
 	"log"
 	"net/http"
 	"os"
+	"time"
+	// Time zones of todosDueToday, on hosts without a zone database.
+	_ "time/tzdata"
 
 	"github.com/99designs/gqlgen/graphql/handler"
-	"github.com/99designs/gqlgen/graphql/playground"
+	"github.com/99designs/gqlgen/graphql/handler/extension"
+	"github.com/99designs/gqlgen/graphql/handler/lru"
+	"github.com/99designs/gqlgen/graphql/handler/transport"
 )
 
 const defaultPort = "8080"
 
+var dataDir = flag.String("data", "", "directory the todos are saved in, as a snapshot and a write-ahead log, kept in memory if empty")
+
 func main() {
+	flag.Parse()
 	port := os.Getenv("PORT")
 	if port == "" {
 		port = defaultPort
 	}
 
-	srv := handler.NewDefaultServer(generated.NewExecutableSchema(generated.Config{Resolvers: &graph.Resolver{}}))
+	var repository store.TodoRepository = store.NewMemoryTodoRepository()
+	if *dataDir != "" {
+		f, err := store.OpenFileTodoRepository(*dataDir)
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer f.Close()
+		repository = f
+	}
+
+	// The transports of handler.NewDefaultServer, with Server-Sent Events
+	// ahead of GET and POST for the requests accepting them.
+	srv := handler.New(generated.NewExecutableSchema(generated.Config{Resolvers: &graph.Resolver{Repository: repository}}))
+	srv.AddTransport(transport.Websocket{KeepAlivePingInterval: 10 * time.Second})
+	srv.AddTransport(sse.Transport{KeepAlivePingInterval: 10 * time.Second})
+	srv.AddTransport(transport.Options{})
+	srv.AddTransport(transport.GET{})
+	srv.AddTransport(transport.POST{})
+	srv.AddTransport(transport.MultipartForm{})
+	srv.SetQueryCache(lru.New(1000))
+	srv.Use(extension.Introspection{})
+	srv.Use(extension.AutomaticPersistedQuery{Cache: lru.New(100)})
+	srv.SetErrorPresenter(apperr.ErrorPresenter)
+	srv.Use(graph.SlowConsumers{})
+	m := metrics.New()
+	srv.Use(metrics.Extension{Metrics: m})
 
-	http.Handle("/", playground.Handler("GraphQL playground", "/query"))
+	http.Handle("/", explorer.Handler(explorer.Config{Title: "Todos", Endpoint: "/query"}))
 	http.Handle("/query", srv)
+	http.Handle("/metrics", m)
 
-	log.Printf("connect to http://localhost:%s/ for GraphQL playground", port)
+	log.Printf("connect to http://localhost:%s/ for the GraphQL explorer", port)
 	log.Fatal(http.ListenAndServe(":"+port, nil))
 }
@@ -0,0 +1,217 @@
+// Package sse is a gqlgen transport that streams the responses of an
+// operation as Server-Sent Events, for the clients of subscriptions that
+// cannot use websockets, such as a browser EventSource.
+//
+// A request is a GET with the query, operationName, variables and extensions
+// URL parameters, or a POST with an application/json body, either accepting
+// text/event-stream. A GET may not be a mutation. Every response is sent as a next event whose data is
+// the JSON response, and the end of the operation as a complete event:
+//
+//	event: next
+//	data: {"data":{"todoChanged":{"kind":"CREATED"}}}
+//
+//	event: complete
+//	data:
+//
+// Queries and mutations are answered with a single next event. The stream
+// ends when the client closes the connection.
+package sse
+
+import (
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+// Transport serves the requests accepting text/event-stream. It is added to
+// a server before the GET and POST transports, which would otherwise take
+// these requests.
+type Transport struct {
+	// KeepAlivePingInterval is the interval at which a comment is sent to
+	// keep an idle stream open through proxies, none if 0.
+	KeepAlivePingInterval time.Duration
+}
+
+var _ graphql.Transport = Transport{}
+
+func (t Transport) Supports(r *http.Request) bool {
+	if r.Header.Get("Upgrade") != "" || r.Method != http.MethodGet && r.Method != http.MethodPost {
+		return false
+	}
+	for _, accept := range strings.Split(r.Header.Get("Accept"), ",") {
+		if mediaType, _, err := mime.ParseMediaType(accept); err == nil && mediaType == "text/event-stream" {
+			return true
+		}
+	}
+	return false
+}
+
+func (t Transport) Do(w http.ResponseWriter, r *http.Request, exec graphql.GraphExecutor) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming is not supported", http.StatusInternalServerError)
+		return
+	}
+
+	if r.Method == http.MethodPost {
+		if mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); mediaType != "application/json" {
+			writeError(w, http.StatusUnsupportedMediaType, "POST requests must have an application/json body")
+			return
+		}
+	}
+	params, err := readParams(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	rc, gqlErr := exec.CreateOperationContext(r.Context(), params)
+	// A GET can be sent across sites by a link or an EventSource, so it
+	// must not change anything.
+	if gqlErr == nil && r.Method == http.MethodGet && rc.Operation.Operation == ast.Mutation {
+		writeError(w, http.StatusNotAcceptable, "GET requests only allow query and subscription operations")
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	s := &stream{w: w, flusher: flusher}
+	s.flush()
+
+	if gqlErr != nil {
+		s.next(exec.DispatchError(graphql.WithOperationContext(r.Context(), rc), gqlErr))
+		s.complete()
+		return
+	}
+
+	if t.KeepAlivePingInterval != 0 {
+		ticker := time.NewTicker(t.KeepAlivePingInterval)
+		done := make(chan struct{})
+		var wg sync.WaitGroup
+		wg.Add(1)
+		// The pings stop before Do returns, after which w must not be
+		// written to.
+		defer func() {
+			ticker.Stop()
+			close(done)
+			wg.Wait()
+		}()
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-ticker.C:
+					s.ping()
+				case <-done:
+					return
+				}
+			}
+		}()
+	}
+
+	ctx := graphql.WithOperationContext(r.Context(), rc)
+	defer func() {
+		if err := recover(); err != nil {
+			s.next(&graphql.Response{Errors: gqlerror.List{{Message: rc.Recover(ctx, err).Error()}}})
+			s.complete()
+		}
+	}()
+	responses, ctx := exec.DispatchOperation(ctx, rc)
+	for {
+		response := responses(ctx)
+		if response == nil {
+			break
+		}
+		s.next(response)
+	}
+	s.complete()
+}
+
+// readParams reads the parameters of an operation from the URL of a GET
+// request or the JSON body of a POST one.
+func readParams(r *http.Request) (*graphql.RawParams, error) {
+	start := graphql.Now()
+	params := &graphql.RawParams{}
+	if r.Method == http.MethodGet {
+		query := r.URL.Query()
+		params.Query, params.OperationName = query.Get("query"), query.Get("operationName")
+		if variables := query.Get("variables"); variables != "" {
+			if err := decode(variables, &params.Variables); err != nil {
+				return nil, fmt.Errorf("variables could not be decoded: %v", err)
+			}
+		}
+		if extensions := query.Get("extensions"); extensions != "" {
+			if err := decode(extensions, &params.Extensions); err != nil {
+				return nil, fmt.Errorf("extensions could not be decoded: %v", err)
+			}
+		}
+	} else {
+		dec := json.NewDecoder(r.Body)
+		dec.UseNumber()
+		if err := dec.Decode(params); err != nil {
+			return nil, fmt.Errorf("json body could not be decoded: %v", err)
+		}
+	}
+	params.ReadTime = graphql.TraceTiming{Start: start, End: graphql.Now()}
+	return params, nil
+}
+
+// writeError answers a request that is not streamed with a JSON error.
+func writeError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(&graphql.Response{Errors: gqlerror.List{{Message: message}}})
+}
+
+func decode(s string, v interface{}) error {
+	dec := json.NewDecoder(strings.NewReader(s))
+	dec.UseNumber()
+	return dec.Decode(v)
+}
+
+// stream writes the events of a response, from the goroutine of the
+// operation and the one of the keep-alive pings.
+type stream struct {
+	mu      sync.Mutex
+	w       http.ResponseWriter
+	flusher http.Flusher
+}
+
+func (s *stream) next(response *graphql.Response) {
+	b, err := json.Marshal(response)
+	if err != nil {
+		panic(err)
+	}
+	s.write("event: next\ndata: %s\n\n", b)
+}
+
+func (s *stream) complete() {
+	s.write("event: complete\ndata:\n\n")
+}
+
+func (s *stream) ping() {
+	s.write(":\n\n")
+}
+
+func (s *stream) write(format string, args ...interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	fmt.Fprintf(s.w, format, args...)
+	s.flusher.Flush()
+}
+
+func (s *stream) flush() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.flusher.Flush()
+}
@@ -0,0 +1,186 @@
+package sse_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"graphql/gqlgen/graph"
+	"graphql/gqlgen/graph/generated"
+	"graphql/gqlgen/sse"
+	"graphql/gqlgen/store"
+
+	"github.com/99designs/gqlgen/graphql/handler"
+	"github.com/99designs/gqlgen/graphql/handler/transport"
+)
+
+func newServer(t *testing.T) *httptest.Server {
+	srv := handler.New(generated.NewExecutableSchema(generated.Config{Resolvers: &graph.Resolver{Repository: store.NewMemoryTodoRepository()}}))
+	srv.AddTransport(sse.Transport{KeepAlivePingInterval: 10 * time.Millisecond})
+	srv.AddTransport(transport.POST{})
+	s := httptest.NewServer(srv)
+	t.Cleanup(s.Close)
+	return s
+}
+
+func get(ctx context.Context, t *testing.T, s *httptest.Server, query string) *http.Response {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.URL+"?query="+url.QueryEscape(query), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Accept", "text/event-stream")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp
+}
+
+func post(t *testing.T, s *httptest.Server, contentType, accept, query string) *http.Response {
+	body, _ := json.Marshal(map[string]string{"query": query})
+	req, err := http.NewRequest(http.MethodPost, s.URL, strings.NewReader(string(body)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("Accept", accept)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp
+}
+
+// events reads the events of a stream, skipping the keep-alive pings.
+func events(t *testing.T, resp *http.Response, n int) []string {
+	var events []string
+	scanner := bufio.NewScanner(resp.Body)
+	var event string
+	for len(events) < n && scanner.Scan() {
+		switch line := scanner.Text(); {
+		case line == "":
+			if event != "" {
+				events = append(events, strings.TrimSuffix(event, "\n"))
+			}
+			event = ""
+		case line == ":":
+		default:
+			event += line + "\n"
+		}
+	}
+	if len(events) < n {
+		t.Fatalf("got events %q, want %d: %v", events, n, scanner.Err())
+	}
+	return events
+}
+
+func users(t *testing.T, s *httptest.Server) int {
+	resp := post(t, s, "application/json", "application/json", `{ users { id } }`)
+	defer resp.Body.Close()
+	var result struct {
+		Data struct{ Users []struct{ ID string } }
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		t.Fatal(err)
+	}
+	return len(result.Data.Users)
+}
+
+func TestQuery(t *testing.T) {
+	s := newServer(t)
+	resp := get(context.Background(), t, s, `{ users { id } }`)
+	defer resp.Body.Close()
+	if got := resp.Header.Get("Content-Type"); got != "text/event-stream" {
+		t.Errorf("got content type %q, want text/event-stream", got)
+	}
+	want := []string{"event: next\ndata: {\"data\":{\"users\":[]}}", "event: complete\ndata:"}
+	if got := events(t, resp, 2); strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Errorf("got events %q, want %q", got, want)
+	}
+}
+
+func TestMutation(t *testing.T) {
+	const mutation = `mutation { createUser(input: {name: "Leia"}) { name } }`
+	tests := []struct {
+		name    string
+		do      func(*testing.T, *httptest.Server) *http.Response
+		status  int
+		created bool
+	}{
+		{"GET", func(t *testing.T, s *httptest.Server) *http.Response {
+			return get(context.Background(), t, s, mutation)
+		}, http.StatusNotAcceptable, false},
+		{"POST of plain text", func(t *testing.T, s *httptest.Server) *http.Response {
+			return post(t, s, "text/plain", "text/event-stream", mutation)
+		}, http.StatusUnsupportedMediaType, false},
+		{"POST of a form", func(t *testing.T, s *httptest.Server) *http.Response {
+			return post(t, s, "application/x-www-form-urlencoded", "text/event-stream", mutation)
+		}, http.StatusUnsupportedMediaType, false},
+		{"POST of JSON", func(t *testing.T, s *httptest.Server) *http.Response {
+			return post(t, s, "application/json; charset=utf-8", "text/event-stream", mutation)
+		}, http.StatusOK, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := newServer(t)
+			resp := test.do(t, s)
+			if resp.StatusCode != test.status {
+				t.Errorf("got status %d, want %d", resp.StatusCode, test.status)
+			}
+			if test.created {
+				want := "event: next\ndata: {\"data\":{\"createUser\":{\"name\":\"Leia\"}}}"
+				if got := events(t, resp, 1)[0]; got != want {
+					t.Errorf("got event %q, want %q", got, want)
+				}
+			}
+			resp.Body.Close()
+			if created := users(t, s) == 1; created != test.created {
+				t.Errorf("got user created %v, want %v", created, test.created)
+			}
+		})
+	}
+}
+
+func TestSubscription(t *testing.T) {
+	s := newServer(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	resp := get(ctx, t, s, `subscription { todoChanged { kind todo { text } } }`)
+	defer resp.Body.Close()
+
+	// The subscription is only known to have started once it sends an
+	// event, so todos are created until it does.
+	created := make(chan struct{})
+	go func() {
+		defer close(created)
+		r := post(t, s, "application/json", "application/json", `mutation { createUser(input: {name: "Luke"}) { id } }`)
+		var result struct {
+			Data struct{ CreateUser struct{ ID string } }
+		}
+		json.NewDecoder(r.Body).Decode(&result)
+		r.Body.Close()
+		ticker := time.NewTicker(10 * time.Millisecond)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				post(t, s, "application/json", "application/json", `mutation { createTodo(input: {text: "Dagobah", userId: "`+result.Data.CreateUser.ID+`"}) { id } }`).Body.Close()
+			}
+		}
+	}()
+
+	want := "event: next\ndata: {\"data\":{\"todoChanged\":{\"kind\":\"CREATED\",\"todo\":{\"text\":\"Dagobah\"}}}}"
+	if got := events(t, resp, 1)[0]; got != want {
+		t.Errorf("got event %q, want %q", got, want)
+	}
+	cancel()
+	<-created
+}
@@ -0,0 +1,274 @@
+package store
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"graphql/gqlgen/graph/model"
+	"io"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+)
+
+const (
+	snapshotFile = "snapshot.json"
+	logFile      = "wal.log"
+	// snapshotEvery is the number of changes logged before they are folded
+	// into a new snapshot.
+	snapshotEvery = 1000
+)
+
+// FileTodoRepository is a TodoRepository saved in a directory, as a JSON
+// snapshot of its content and a write-ahead log of the changes made since,
+// one JSON line each. A change is synced to the log before it is applied,
+// and the log is replayed when the repository is opened, so that a change
+// that succeeded survives a crash. It is safe for concurrent use.
+type FileTodoRepository struct {
+	dir    string
+	memory *MemoryTodoRepository
+	log    *os.File
+	// seq is the sequence number of the last change, logged is the number
+	// of changes in the log. Both are guarded by the lock of memory.
+	seq    int
+	logged int
+}
+
+type snapshot struct {
+	// Seq is the sequence number of the last change in the snapshot. The
+	// changes of the log up to it were already applied.
+	Seq int `json:"seq"`
+	state
+}
+
+// OpenFileTodoRepository opens the repository saved in dir, creating it if
+// needed. The changes logged since the last snapshot are replayed, a change
+// partially written by a crash discarded, and the result saved as a new
+// snapshot.
+func OpenFileTodoRepository(dir string) (*FileTodoRepository, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	f := &FileTodoRepository{dir: dir, memory: NewMemoryTodoRepository()}
+
+	b, err := ioutil.ReadFile(filepath.Join(dir, snapshotFile))
+	switch {
+	case os.IsNotExist(err):
+	case err != nil:
+		return nil, err
+	default:
+		var snap snapshot
+		if err := json.Unmarshal(b, &snap); err != nil {
+			return nil, fmt.Errorf("%s: %v", filepath.Join(dir, snapshotFile), err)
+		}
+		f.seq, f.memory.state = snap.Seq, snap.state
+	}
+
+	f.log, err = os.OpenFile(filepath.Join(dir, logFile), os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return nil, err
+	}
+	if err := f.replay(); err != nil {
+		f.log.Close()
+		return nil, err
+	}
+	for _, todo := range f.memory.state.Todos {
+		// Todos saved before they had priorities and tags.
+		if todo.Priority == "" {
+			todo.Priority = model.PriorityMedium
+		}
+		if todo.Tags == nil {
+			todo.Tags = []string{}
+		}
+	}
+	if f.logged > 0 {
+		if err := f.compact(); err != nil {
+			f.log.Close()
+			return nil, err
+		}
+	}
+	return f, nil
+}
+
+// replay applies the changes of the log that are not in the snapshot and
+// truncates a trailing partial record left behind by a crash in the middle
+// of a write.
+func (f *FileTodoRepository) replay() error {
+	reader := bufio.NewReader(f.log)
+	var offset int64
+	for line := 1; ; line++ {
+		b, err := reader.ReadBytes('\n')
+		if err == io.EOF {
+			if len(b) > 0 {
+				if err := f.log.Truncate(offset); err != nil {
+					return err
+				}
+			}
+			break
+		}
+		if err != nil {
+			return err
+		}
+		var r record
+		if err := json.Unmarshal(b, &r); err != nil {
+			return fmt.Errorf("%s:%d: %v", f.log.Name(), line, err)
+		}
+		offset += int64(len(b))
+		f.logged++
+		if r.Seq <= f.seq {
+			continue
+		}
+		if err := f.memory.apply(r); err != nil {
+			return fmt.Errorf("%s:%d: %v", f.log.Name(), line, err)
+		}
+		f.seq = r.Seq
+	}
+	_, err := f.log.Seek(offset, io.SeekStart)
+	return err
+}
+
+// commit writes r to the log. The caller holds the lock of memory.
+func (f *FileTodoRepository) commit(r record) error {
+	r.Seq = f.seq + 1
+	b, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	offset, err := f.log.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return err
+	}
+	_, err = f.log.Write(append(b, '\n'))
+	if err == nil {
+		err = f.log.Sync()
+	}
+	if err != nil {
+		// A partial record would be followed by the next ones, so it is
+		// removed rather than left for replay to truncate.
+		if terr := f.log.Truncate(offset); terr == nil {
+			f.log.Seek(offset, io.SeekStart)
+		}
+		return err
+	}
+	f.seq++
+	f.logged++
+	return nil
+}
+
+// compactIfNeeded folds the log into a new snapshot once it holds
+// snapshotEvery changes.
+func (f *FileTodoRepository) compactIfNeeded() error {
+	f.memory.mu.Lock()
+	defer f.memory.mu.Unlock()
+	if f.logged < snapshotEvery {
+		return nil
+	}
+	return f.compact()
+}
+
+// compact saves the content of the repository as a new snapshot and empties
+// the log. A crash before the log is emptied leaves changes that are both in
+// the snapshot and the log, which replay skips. The caller holds the lock of
+// memory, or is the only user of the repository.
+func (f *FileTodoRepository) compact() error {
+	b, err := json.Marshal(snapshot{Seq: f.seq, state: f.memory.state})
+	if err != nil {
+		return err
+	}
+	tmp, err := ioutil.TempFile(f.dir, snapshotFile+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(b); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp.Name(), filepath.Join(f.dir, snapshotFile)); err != nil {
+		return err
+	}
+	if err := syncDir(f.dir); err != nil {
+		return err
+	}
+
+	if err := f.log.Truncate(0); err != nil {
+		return err
+	}
+	if _, err := f.log.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	f.logged = 0
+	return f.log.Sync()
+}
+
+// syncDir makes a rename in dir durable.
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+	return d.Sync()
+}
+
+// changed compacts the log if needed once a change succeeded. A failure to
+// compact is logged rather than returned, as the change itself is saved.
+func (f *FileTodoRepository) changed(err error) error {
+	if err == nil {
+		if err := f.compactIfNeeded(); err != nil {
+			log.Printf("compacting %s: %v", f.dir, err)
+		}
+	}
+	return err
+}
+
+func (f *FileTodoRepository) CreateUser(name string) (*model.User, error) {
+	user, err := f.memory.createUser(name, f.commit)
+	return user, f.changed(err)
+}
+
+func (f *FileTodoRepository) User(id string) (*model.User, error) {
+	return f.memory.User(id)
+}
+
+func (f *FileTodoRepository) Users() ([]*model.User, error) {
+	return f.memory.Users()
+}
+
+func (f *FileTodoRepository) CreateTodo(todo model.Todo) (*model.Todo, error) {
+	created, err := f.memory.createTodo(todo, f.commit)
+	return created, f.changed(err)
+}
+
+func (f *FileTodoRepository) Todo(id string) (*model.Todo, error) {
+	return f.memory.Todo(id)
+}
+
+func (f *FileTodoRepository) Todos() ([]*model.Todo, error) {
+	return f.memory.Todos()
+}
+
+func (f *FileTodoRepository) UpdateTodo(id string, update func(todo *model.Todo)) (*model.Todo, error) {
+	updated, err := f.memory.updateTodo(id, update, f.commit)
+	return updated, f.changed(err)
+}
+
+func (f *FileTodoRepository) DeleteTodo(id string) (*model.Todo, error) {
+	deleted, err := f.memory.deleteTodo(id, f.commit)
+	return deleted, f.changed(err)
+}
+
+// Close closes the log.
+func (f *FileTodoRepository) Close() error {
+	f.memory.mu.Lock()
+	defer f.memory.mu.Unlock()
+	return f.log.Close()
+}
@@ -0,0 +1,177 @@
+package store
+
+import (
+	"encoding/json"
+	"graphql/gqlgen/graph/model"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// fill makes a user with two todos, one updated and the other deleted, and
+// returns the content of f.
+func fill(t *testing.T, f *FileTodoRepository) state {
+	t.Helper()
+	user, err := f.CreateUser("Leia")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, text := range []string{"Alderaan", "Hoth"} {
+		if _, err := f.CreateTodo(model.Todo{Text: text, UserID: user.ID, Priority: model.PriorityHigh, Tags: []string{"rebels"}}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := f.UpdateTodo("T1", func(todo *model.Todo) { todo.Done = true }); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.DeleteTodo("T2"); err != nil {
+		t.Fatal(err)
+	}
+	return content(f)
+}
+
+func content(f *FileTodoRepository) state {
+	f.memory.mu.RLock()
+	defer f.memory.mu.RUnlock()
+	return f.memory.state
+}
+
+func open(t *testing.T, dir string) *FileTodoRepository {
+	t.Helper()
+	f, err := OpenFileTodoRepository(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { f.Close() })
+	return f
+}
+
+func logSize(t *testing.T, dir string) int64 {
+	t.Helper()
+	info, err := os.Stat(filepath.Join(dir, logFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return info.Size()
+}
+
+func TestFileReopen(t *testing.T) {
+	dir := t.TempDir()
+	f := open(t, dir)
+	want := fill(t, f)
+	f.Close()
+	if logSize(t, dir) == 0 {
+		t.Fatal("the changes were not logged")
+	}
+
+	f = open(t, dir)
+	if got := content(f); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v after reopening, want %+v", got, want)
+	}
+	if size := logSize(t, dir); size != 0 {
+		t.Errorf("the log holds %d bytes after opening, want it compacted", size)
+	}
+	todo, err := f.CreateTodo(model.Todo{Text: "Endor", UserID: "U1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if todo.ID != "T3" {
+		t.Errorf("got ID %s after reopening, want T3", todo.ID)
+	}
+}
+
+func TestFileHalfWrittenRecord(t *testing.T) {
+	dir := t.TempDir()
+	f := open(t, dir)
+	want := fill(t, f)
+	f.Close()
+	// A crash in the middle of writing the next change.
+	l, err := os.OpenFile(filepath.Join(dir, logFile), os.O_WRONLY|os.O_APPEND, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	l.WriteString(`{"seq":6,"op":"createTodo","todo":{"id":"T3","te`)
+	l.Close()
+
+	f = open(t, dir)
+	if got := content(f); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want the partial change discarded: %+v", got, want)
+	}
+	if _, err := f.CreateUser("Han"); err != nil {
+		t.Fatal(err)
+	}
+	want = content(f)
+	f.Close()
+	f = open(t, dir)
+	if got := content(f); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v after a change following the partial one, want %+v", got, want)
+	}
+}
+
+func TestFileCrashAfterSnapshot(t *testing.T) {
+	dir := t.TempDir()
+	f := open(t, dir)
+	fill(t, f)
+	logged, err := ioutil.ReadFile(filepath.Join(dir, logFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := f.compact(); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+	// A crash between the rename of the snapshot and the truncation of the
+	// log, followed by a change logged after the snapshot.
+	b, _ := json.Marshal(record{Seq: 6, Op: createUser, User: &model.User{ID: "U2", Name: "Han"}})
+	logged = append(logged, append(b, '\n')...)
+	if err := ioutil.WriteFile(filepath.Join(dir, logFile), logged, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	f = open(t, dir)
+	got := content(f)
+	if len(got.Users) != 2 || got.LastUser != 2 || got.Users[1].Name != "Han" {
+		t.Errorf("got users %+v, last %d, want Leia then Han, last 2", got.Users, got.LastUser)
+	}
+	if len(got.Todos) != 1 || got.LastTodo != 2 || !got.Todos[0].Done {
+		t.Errorf("got todos %+v, last %d, want T1 done, last 2", got.Todos, got.LastTodo)
+	}
+}
+
+func TestFileFailedWrite(t *testing.T) {
+	dir := t.TempDir()
+	f := open(t, dir)
+	want := fill(t, f)
+	seq := f.seq
+
+	writable := f.log
+	readOnly, err := os.Open(writable.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.log = readOnly
+	if _, err := f.CreateUser("Han"); err == nil {
+		t.Fatal("created a user that could not be logged")
+	}
+	f.log = writable
+	readOnly.Close()
+	if got := content(f); !reflect.DeepEqual(got, want) || f.seq != seq {
+		t.Errorf("got %+v at %d after a failed write, want %+v at %d", got, f.seq, want, seq)
+	}
+
+	user, err := f.CreateUser("Han")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if user.ID != "U2" {
+		t.Errorf("got ID %s, want U2", user.ID)
+	}
+	want = content(f)
+	f.Close()
+	f = open(t, dir)
+	if got := content(f); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v after reopening, want %+v", got, want)
+	}
+}
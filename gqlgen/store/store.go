@@ -0,0 +1,243 @@
+// Package store keeps the todos and users of the todo app.
+package store
+
+import (
+	"errors"
+	"fmt"
+	"graphql/gqlgen/graph/model"
+	"sync"
+)
+
+// ErrUnknownUser is returned when creating a todo for a user that does not
+// exist.
+var ErrUnknownUser = errors.New("unknown user")
+
+// TodoRepository keeps the todos and the users they belong to. IDs are
+// assigned by the repository: T or U followed by a sequence number, never
+// reused even after a deletion.
+type TodoRepository interface {
+	// CreateUser adds a user with the given name.
+	CreateUser(name string) (*model.User, error)
+	// User returns the user id, or nil if there is none.
+	User(id string) (*model.User, error)
+	// Users returns the users in the order they were created.
+	Users() ([]*model.User, error)
+
+	// CreateTodo adds todo, ignoring its ID. It fails with ErrUnknownUser if
+	// its user does not exist.
+	CreateTodo(todo model.Todo) (*model.Todo, error)
+	// Todo returns the todo id, or nil if there is none.
+	Todo(id string) (*model.Todo, error)
+	// Todos returns the todos in the order they were created.
+	Todos() ([]*model.Todo, error)
+	// UpdateTodo replaces the todo id with what update makes of a copy of it
+	// and returns it, or nil if there is none. The ID and the user of a todo
+	// cannot be changed.
+	UpdateTodo(id string, update func(todo *model.Todo)) (*model.Todo, error)
+	// DeleteTodo removes the todo id and returns it, or nil if there is none.
+	DeleteTodo(id string) (*model.Todo, error)
+}
+
+// state is the content of a repository, as saved in a snapshot.
+type state struct {
+	LastUser int           `json:"lastUser"`
+	LastTodo int           `json:"lastTodo"`
+	Users    []*model.User `json:"users"`
+	Todos    []*model.Todo `json:"todos"`
+}
+
+// record is a change to a repository. It holds the values the change
+// results in rather than the arguments it was made with, so that replaying
+// it does not depend on anything but the state it is applied to.
+type record struct {
+	Seq  int         `json:"seq"`
+	Op   op          `json:"op"`
+	User *model.User `json:"user,omitempty"`
+	Todo *model.Todo `json:"todo,omitempty"`
+}
+
+type op string
+
+const (
+	createUser op = "createUser"
+	createTodo op = "createTodo"
+	updateTodo op = "updateTodo"
+	deleteTodo op = "deleteTodo"
+)
+
+// MemoryTodoRepository is a TodoRepository that lives only as long as the
+// process. It is safe for concurrent use.
+type MemoryTodoRepository struct {
+	mu    sync.RWMutex
+	state state
+}
+
+func NewMemoryTodoRepository() *MemoryTodoRepository {
+	return &MemoryTodoRepository{}
+}
+
+// change makes the record returned by prepare, passes it to commit if not
+// nil, then applies it, all under the lock of the repository so that no
+// other change comes in between.
+func (m *MemoryTodoRepository) change(prepare func() (record, error), commit func(record) error) (record, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	r, err := prepare()
+	if err != nil || r.Op == "" {
+		return r, err
+	}
+	if commit != nil {
+		if err := commit(r); err != nil {
+			return r, err
+		}
+	}
+	return r, m.apply(r)
+}
+
+// apply applies r to the state. The caller holds mu.
+func (m *MemoryTodoRepository) apply(r record) error {
+	s := &m.state
+	switch r.Op {
+	case createUser:
+		s.Users = append(s.Users, r.User)
+		s.LastUser++
+	case createTodo:
+		s.Todos = append(s.Todos, r.Todo)
+		s.LastTodo++
+	case updateTodo:
+		i := m.findTodo(r.Todo.ID)
+		if i < 0 {
+			return fmt.Errorf("todo %q to update not found", r.Todo.ID)
+		}
+		// Todos are replaced rather than modified, as readers may still hold the old one.
+		s.Todos[i] = r.Todo
+	case deleteTodo:
+		i := m.findTodo(r.Todo.ID)
+		if i < 0 {
+			return fmt.Errorf("todo %q to delete not found", r.Todo.ID)
+		}
+		todos := make([]*model.Todo, 0, len(s.Todos)-1)
+		todos = append(todos, s.Todos[:i]...)
+		s.Todos = append(todos, s.Todos[i+1:]...)
+	default:
+		return fmt.Errorf("unknown operation %q", r.Op)
+	}
+	return nil
+}
+
+// findTodo returns the index of the todo id, -1 if there is none. The
+// caller holds mu.
+func (m *MemoryTodoRepository) findTodo(id string) int {
+	for i, todo := range m.state.Todos {
+		if todo.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// findUser returns the user id, nil if there is none. The caller holds mu.
+func (m *MemoryTodoRepository) findUser(id string) *model.User {
+	for _, user := range m.state.Users {
+		if user.ID == id {
+			return user
+		}
+	}
+	return nil
+}
+
+func (m *MemoryTodoRepository) createUser(name string, commit func(record) error) (*model.User, error) {
+	r, err := m.change(func() (record, error) {
+		user := &model.User{ID: fmt.Sprintf("U%d", m.state.LastUser+1), Name: name}
+		return record{Op: createUser, User: user}, nil
+	}, commit)
+	return r.User, err
+}
+
+func (m *MemoryTodoRepository) createTodo(todo model.Todo, commit func(record) error) (*model.Todo, error) {
+	r, err := m.change(func() (record, error) {
+		if m.findUser(todo.UserID) == nil {
+			return record{}, ErrUnknownUser
+		}
+		todo.ID = fmt.Sprintf("T%d", m.state.LastTodo+1)
+		return record{Op: createTodo, Todo: &todo}, nil
+	}, commit)
+	return r.Todo, err
+}
+
+func (m *MemoryTodoRepository) updateTodo(id string, update func(*model.Todo), commit func(record) error) (*model.Todo, error) {
+	r, err := m.change(func() (record, error) {
+		i := m.findTodo(id)
+		if i < 0 {
+			return record{}, nil
+		}
+		updated := *m.state.Todos[i]
+		update(&updated)
+		updated.ID, updated.UserID = id, m.state.Todos[i].UserID
+		return record{Op: updateTodo, Todo: &updated}, nil
+	}, commit)
+	return r.Todo, err
+}
+
+func (m *MemoryTodoRepository) deleteTodo(id string, commit func(record) error) (*model.Todo, error) {
+	r, err := m.change(func() (record, error) {
+		i := m.findTodo(id)
+		if i < 0 {
+			return record{}, nil
+		}
+		return record{Op: deleteTodo, Todo: m.state.Todos[i]}, nil
+	}, commit)
+	return r.Todo, err
+}
+
+func (m *MemoryTodoRepository) CreateUser(name string) (*model.User, error) {
+	return m.createUser(name, nil)
+}
+
+func (m *MemoryTodoRepository) User(id string) (*model.User, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.findUser(id), nil
+}
+
+func (m *MemoryTodoRepository) Users() ([]*model.User, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	users := make([]*model.User, len(m.state.Users))
+	copy(users, m.state.Users)
+	return users, nil
+}
+
+func (m *MemoryTodoRepository) CreateTodo(todo model.Todo) (*model.Todo, error) {
+	return m.createTodo(todo, nil)
+}
+
+func (m *MemoryTodoRepository) Todo(id string) (*model.Todo, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if i := m.findTodo(id); i >= 0 {
+		return m.state.Todos[i], nil
+	}
+	return nil, nil
+}
+
+func (m *MemoryTodoRepository) Todos() ([]*model.Todo, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	todos := make([]*model.Todo, len(m.state.Todos))
+	copy(todos, m.state.Todos)
+	return todos, nil
+}
+
+func (m *MemoryTodoRepository) UpdateTodo(id string, update func(todo *model.Todo)) (*model.Todo, error) {
+	return m.updateTodo(id, update, nil)
+}
+
+func (m *MemoryTodoRepository) DeleteTodo(id string) (*model.Todo, error) {
+	return m.deleteTodo(id, nil)
+}
+
+var (
+	_ TodoRepository = (*MemoryTodoRepository)(nil)
+	_ TodoRepository = (*FileTodoRepository)(nil)
+)
@@ -0,0 +1,46 @@
+package store
+
+import (
+	"graphql/gqlgen/graph/model"
+	"testing"
+)
+
+func TestMemoryIDsAfterDelete(t *testing.T) {
+	m := NewMemoryTodoRepository()
+	user, err := m.CreateUser("Leia")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ids []string
+	for i := 0; i < 3; i++ {
+		todo, err := m.CreateTodo(model.Todo{Text: "todo", UserID: user.ID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, todo.ID)
+		if _, err := m.DeleteTodo(todo.ID); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want := []string{"T1", "T2", "T3"}
+	for i := range want {
+		if ids[i] != want[i] {
+			t.Errorf("got IDs %v, want %v", ids, want)
+			break
+		}
+	}
+	if todos, _ := m.Todos(); len(todos) != 0 {
+		t.Errorf("got %d todos, want none", len(todos))
+	}
+}
+
+func TestMemoryUnknownUser(t *testing.T) {
+	m := NewMemoryTodoRepository()
+	if _, err := m.CreateTodo(model.Todo{Text: "todo", UserID: "U1"}); err != ErrUnknownUser {
+		t.Errorf("got error %v, want %v", err, ErrUnknownUser)
+	}
+	user, _ := m.CreateUser("Leia")
+	if todo, _ := m.CreateTodo(model.Todo{Text: "todo", UserID: user.ID}); todo.ID != "T1" {
+		t.Errorf("got ID %s after a failed creation, want T1", todo.ID)
+	}
+}
@@ -1,6 +1,7 @@
 package data
 
 import (
+	"graphql/dataset"
 	"graphql/graphql-starwar/model"
 
 	"github.com/golang/protobuf/proto"
@@ -10,135 +11,86 @@ var (
 	Humans    map[string]*model.Human
 	Droids    map[string]*model.Droid
 	Starships map[string]*model.Starship
-	Reviews   map[model.Episode][]*model.Review
+	Reviews   ReviewStore
 )
 
 func init() {
-	Luke := &model.Human{
-		ID:        "1000",
-		Name:      "Luke Skywalker",
-		AppearsIn: []model.Episode{model.EpisodeNewhope, model.EpisodeEmpire, model.EpisodeJedi},
-		Height:    1.72,
-		Mass:      proto.Float64(77),
-	}
-	Darth := &model.Human{
-		ID:        "1001",
-		Name:      "Darth Vader",
-		AppearsIn: []model.Episode{model.EpisodeNewhope, model.EpisodeEmpire, model.EpisodeJedi},
-		Height:    2.02,
-		Mass:      proto.Float64(136),
-	}
-	Han := &model.Human{
-		ID:        "1002",
-		Name:      "Han Solo",
-		AppearsIn: []model.Episode{model.EpisodeNewhope, model.EpisodeEmpire, model.EpisodeJedi},
-		Height:    1.8,
-		Mass:      proto.Float64(80),
-	}
-	Leia := &model.Human{
-		ID:        "1003",
-		Name:      "Leia Organa",
-		AppearsIn: []model.Episode{model.EpisodeNewhope, model.EpisodeEmpire, model.EpisodeJedi},
-		Height:    1.5,
-		Mass:      proto.Float64(49),
-	}
-	Wilhuff := &model.Human{
-		ID:        "1004",
-		Name:      "Wilhuff Tarkin",
-		AppearsIn: []model.Episode{model.EpisodeNewhope},
-		Height:    1.8,
-		Mass:      proto.Float64(0),
-	}
-	C_3PO := &model.Droid{
-		ID:              "2000",
-		Name:            "C-3PO",
-		AppearsIn:       []model.Episode{model.EpisodeNewhope, model.EpisodeEmpire, model.EpisodeJedi},
-		PrimaryFunction: proto.String("Protocol"),
-	}
-	R2_D2 := &model.Droid{
-		ID:              "2001",
-		Name:            "R2-D2",
-		AppearsIn:       []model.Episode{model.EpisodeNewhope, model.EpisodeEmpire, model.EpisodeJedi},
-		PrimaryFunction: proto.String("Astromech"),
-	}
+	Load(dataset.Default())
+}
 
-	Millennium := &model.Starship{
-		ID:   "3000",
-		Name: "Millennium Falcon",
-		History: [][]int{
-			{1, 2},
-			{4, 5},
-			{1, 2},
-			{3, 2},
-		},
-		Length: 34.37,
-	}
-	X_Wing := &model.Starship{
-		ID:   "3001",
-		Name: "X-Wing",
-		History: [][]int{
-			{6, 4},
-			{3, 2},
-			{2, 3},
-			{5, 1},
-		},
-		Length: 12.5,
+// Load replaces the characters and starships with those of ds, and the
+// reviews with a memory store holding the reviews of ds.
+func Load(ds *dataset.Dataset) {
+	humans := map[string]*model.Human{}
+	droids := map[string]*model.Droid{}
+	starships := map[string]*model.Starship{}
+	characters := map[string]model.Character{}
+
+	for _, s := range ds.Starships {
+		starships[s.ID] = &model.Starship{
+			ID:      s.ID,
+			Name:    s.Name,
+			History: s.History,
+			Length:  s.Length,
+		}
 	}
-	TIE := &model.Starship{
-		ID:   "3002",
-		Name: "TIE Advanced x1",
-		History: [][]int{
-			{3, 2},
-			{7, 2},
-			{6, 4},
-			{3, 2},
-		},
-		Length: 9.2,
+	for _, h := range ds.Humans {
+		human := &model.Human{
+			ID:        h.ID,
+			Name:      h.Name,
+			AppearsIn: episodes(h.AppearsIn),
+			Height:    h.Height,
+			Mass:      h.Mass,
+		}
+		for _, id := range h.Starships {
+			human.Starships = append(human.Starships, starships[id])
+		}
+		humans[h.ID] = human
+		characters[h.ID] = human
 	}
-	Imperial := &model.Starship{
-		ID:   "3003",
-		Name: "Imperial shuttle",
-		History: [][]int{
-			{1, 7},
-			{3, 5},
-			{5, 3},
-			{7, 1},
-		},
-		Length: 20,
+	for _, d := range ds.Droids {
+		droid := &model.Droid{
+			ID:        d.ID,
+			Name:      d.Name,
+			AppearsIn: episodes(d.AppearsIn),
+		}
+		if d.PrimaryFunction != "" {
+			droid.PrimaryFunction = proto.String(d.PrimaryFunction)
+		}
+		droids[d.ID] = droid
+		characters[d.ID] = droid
 	}
 
-	Luke.Friends = []model.Character{Han, Leia, C_3PO, R2_D2}
-	Darth.Friends = []model.Character{Wilhuff}
-	Han.Friends = []model.Character{Luke, Leia, R2_D2}
-	Leia.Friends = []model.Character{Luke, Han, C_3PO, R2_D2}
-	Wilhuff.Friends = []model.Character{Darth}
-	C_3PO.Friends = []model.Character{Luke, Han, Leia, R2_D2}
-	R2_D2.Friends = []model.Character{Luke, Han, Leia}
-
-	Luke.Starships = []*model.Starship{X_Wing, Imperial}
-	Darth.Starships = []*model.Starship{TIE}
-	Han.Starships = []*model.Starship{Millennium, Imperial}
-
-	Humans = map[string]*model.Human{
-		Luke.ID:    Luke,
-		Darth.ID:   Darth,
-		Han.ID:     Han,
-		Leia.ID:    Leia,
-		Wilhuff.ID: Wilhuff,
+	for _, h := range ds.Humans {
+		for _, id := range h.Friends {
+			humans[h.ID].Friends = append(humans[h.ID].Friends, characters[id])
+		}
 	}
-
-	Droids = map[string]*model.Droid{
-		C_3PO.ID: C_3PO,
-		R2_D2.ID: R2_D2,
+	for _, d := range ds.Droids {
+		for _, id := range d.Friends {
+			droids[d.ID].Friends = append(droids[d.ID].Friends, characters[id])
+		}
 	}
 
-	Starships = map[string]*model.Starship{
-		Millennium.ID: Millennium,
-		X_Wing.ID:     X_Wing,
-		TIE.ID:        TIE,
-		Imperial.ID:   Imperial,
+	reviews := NewMemoryReviewStore()
+	for _, r := range ds.Reviews {
+		reviews.Add(model.Episode(r.Episode), &model.Review{
+			Stars:      r.Stars,
+			Commentary: r.Commentary,
+			Time:       r.Time,
+		})
 	}
 
-	Reviews = map[model.Episode][]*model.Review{}
+	Humans = humans
+	Droids = droids
+	Starships = starships
+	Reviews = reviews
+}
 
+func episodes(names []string) []model.Episode {
+	l := make([]model.Episode, len(names))
+	for i, name := range names {
+		l[i] = model.Episode(name)
+	}
+	return l
 }
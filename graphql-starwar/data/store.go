@@ -0,0 +1,136 @@
+package data
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"graphql/graphql-starwar/model"
+	"io"
+	"os"
+	"sync"
+)
+
+// ReviewStore keeps the reviews posted for each episode.
+type ReviewStore interface {
+	// Add appends a review to the given episode.
+	Add(episode model.Episode, review *model.Review) error
+	// List returns the reviews of the given episode in the order they were added.
+	List(episode model.Episode) ([]*model.Review, error)
+}
+
+// MemoryReviewStore is a ReviewStore that lives only as long as the process.
+// It is safe for concurrent use.
+type MemoryReviewStore struct {
+	mu      sync.RWMutex
+	reviews map[model.Episode][]*model.Review
+}
+
+func NewMemoryReviewStore() *MemoryReviewStore {
+	return &MemoryReviewStore{
+		reviews: map[model.Episode][]*model.Review{},
+	}
+}
+
+func (s *MemoryReviewStore) Add(episode model.Episode, review *model.Review) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.reviews[episode] = append(s.reviews[episode], review)
+	return nil
+}
+
+func (s *MemoryReviewStore) List(episode model.Episode) ([]*model.Review, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	reviews := make([]*model.Review, len(s.reviews[episode]))
+	copy(reviews, s.reviews[episode])
+	return reviews, nil
+}
+
+// FileReviewStore is a ReviewStore backed by an append-only log file. Every
+// review is written as one JSON line and the log is replayed when the store
+// is opened, so reviews survive restarts. It is safe for concurrent use.
+type FileReviewStore struct {
+	mu     sync.Mutex
+	file   *os.File
+	memory *MemoryReviewStore
+}
+
+type reviewRecord struct {
+	Episode model.Episode `json:"episode"`
+	Review  *model.Review `json:"review"`
+}
+
+// OpenFileReviewStore opens the log at path, creating it if needed, and
+// replays the reviews it already contains.
+func OpenFileReviewStore(path string) (*FileReviewStore, error) {
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return nil, err
+	}
+	s := &FileReviewStore{
+		file:   file,
+		memory: NewMemoryReviewStore(),
+	}
+	if err := s.replay(); err != nil {
+		file.Close()
+		return nil, err
+	}
+	return s, nil
+}
+
+// replay loads every complete record of the log and truncates a trailing
+// partial record left behind by a crash in the middle of a write.
+func (s *FileReviewStore) replay() error {
+	reader := bufio.NewReader(s.file)
+	var offset int64
+	for line := 1; ; line++ {
+		b, err := reader.ReadBytes('\n')
+		if err == io.EOF {
+			if len(b) > 0 {
+				if err := s.file.Truncate(offset); err != nil {
+					return err
+				}
+			}
+			break
+		}
+		if err != nil {
+			return err
+		}
+		var record reviewRecord
+		if err := json.Unmarshal(b, &record); err != nil {
+			return fmt.Errorf("%s:%d: %v", s.file.Name(), line, err)
+		}
+		s.memory.Add(record.Episode, record.Review)
+		offset += int64(len(b))
+	}
+	_, err := s.file.Seek(offset, io.SeekStart)
+	return err
+}
+
+func (s *FileReviewStore) Add(episode model.Episode, review *model.Review) error {
+	b, err := json.Marshal(reviewRecord{Episode: episode, Review: review})
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, err := s.file.Write(append(b, '\n')); err != nil {
+		return err
+	}
+	if err := s.file.Sync(); err != nil {
+		return err
+	}
+	return s.memory.Add(episode, review)
+}
+
+func (s *FileReviewStore) List(episode model.Episode) ([]*model.Review, error) {
+	return s.memory.List(episode)
+}
+
+// Close closes the underlying log file.
+func (s *FileReviewStore) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.file.Close()
+}
@@ -0,0 +1,120 @@
+package data
+
+import (
+	"graphql/graphql-starwar/model"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func review(stars int) *model.Review {
+	commentary := "review"
+	at := time.Date(1983, 5, 25, 0, 0, 0, 0, time.UTC)
+	return &model.Review{Stars: stars, Commentary: &commentary, Time: &at}
+}
+
+func list(t *testing.T, s ReviewStore, episode model.Episode) []*model.Review {
+	t.Helper()
+	reviews, err := s.List(episode)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return reviews
+}
+
+func TestMemoryReviewStore(t *testing.T) {
+	s := NewMemoryReviewStore()
+	if reviews := list(t, s, model.EpisodeJedi); len(reviews) != 0 {
+		t.Errorf("got %d reviews of an empty store, want none", len(reviews))
+	}
+	want := []*model.Review{review(5), review(3)}
+	for _, r := range want {
+		s.Add(model.EpisodeJedi, r)
+	}
+	s.Add(model.EpisodeEmpire, review(4))
+
+	got := list(t, s, model.EpisodeJedi)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	// The list returned is a copy.
+	got[0] = nil
+	if list(t, s, model.EpisodeJedi)[0] == nil {
+		t.Error("changing a list changed the store")
+	}
+}
+
+func open(t *testing.T, path string) *FileReviewStore {
+	t.Helper()
+	s, err := OpenFileReviewStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { s.Close() })
+	return s
+}
+
+func TestFileReviewStoreReopen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "reviews.log")
+	s := open(t, path)
+	want := []*model.Review{review(5), review(3)}
+	for _, r := range want {
+		if err := s.Add(model.EpisodeJedi, r); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := s.Add(model.EpisodeEmpire, review(4)); err != nil {
+		t.Fatal(err)
+	}
+	s.Close()
+
+	s = open(t, path)
+	if got := list(t, s, model.EpisodeJedi); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v after reopening, want %v", got, want)
+	}
+	if got := list(t, s, model.EpisodeEmpire); len(got) != 1 || got[0].Stars != 4 {
+		t.Errorf("got %v after reopening, want the review of 4 stars", got)
+	}
+}
+
+func TestFileReviewStorePartialRecord(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "reviews.log")
+	s := open(t, path)
+	if err := s.Add(model.EpisodeJedi, review(5)); err != nil {
+		t.Fatal(err)
+	}
+	s.Close()
+	// A crash in the middle of writing the next review.
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.WriteString(`{"episode":"JEDI","review":{"sta`)
+	f.Close()
+
+	s = open(t, path)
+	if got := list(t, s, model.EpisodeJedi); len(got) != 1 {
+		t.Fatalf("got %d reviews, want the partial one discarded", len(got))
+	}
+	if err := s.Add(model.EpisodeJedi, review(1)); err != nil {
+		t.Fatal(err)
+	}
+	s.Close()
+
+	s = open(t, path)
+	if got := list(t, s, model.EpisodeJedi); len(got) != 2 || got[1].Stars != 1 {
+		t.Errorf("got %v, want the review added after the partial one", got)
+	}
+}
+
+func TestFileReviewStoreCorrupt(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "reviews.log")
+	if err := os.WriteFile(path, []byte("not json\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := OpenFileReviewStore(path); err == nil {
+		t.Error("opened a log with a corrupt complete record")
+	}
+}
@@ -1,11 +1,11 @@
 package exec
 
 import (
-	"encoding/base64"
-	"fmt"
+	"graphql/apperr"
+	"graphql/globalid"
 	"graphql/graphql-starwar/data"
 	"graphql/graphql-starwar/model"
-	"strconv"
+	"graphql/pagination"
 	"strings"
 	"time"
 
@@ -15,10 +15,15 @@ import (
 
 var StarWarsSchema graphql.Schema
 
+// StarWarsSchemaConfig is the config StarWarsSchema is made from, to make
+// other schemas of the same types with other extensions.
+var StarWarsSchemaConfig graphql.SchemaConfig
+
 var (
 	episodeEnum    *graphql.Enum
 	lengthUnitEnum *graphql.Enum
 
+	nodeInterface      *graphql.Interface
 	characterInterface *graphql.Interface
 
 	reviewInputType *graphql.InputObject
@@ -69,6 +74,28 @@ func init() {
 		},
 	})
 
+	nodeInterface = graphql.NewInterface(graphql.InterfaceConfig{
+		Name:        "Node",
+		Description: "An object with a global ID",
+		Fields: graphql.Fields{
+			"id": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.ID),
+				Description: "The global ID of the object",
+			},
+		},
+		ResolveType: func(p graphql.ResolveTypeParams) *graphql.Object {
+			switch p.Value.(type) {
+			case *model.Human:
+				return humanType
+			case *model.Droid:
+				return droidType
+			case *model.Starship:
+				return starShipType
+			}
+			return nil
+		},
+	})
+
 	characterInterface = graphql.NewInterface(graphql.InterfaceConfig{
 		Name:        "Character",
 		Description: "A character in the Star Wars Trilogy",
@@ -116,7 +143,7 @@ func init() {
 					if human, ok := p.Source.(*model.FriendsEdge); ok {
 						return human.Cursor, nil
 					}
-					return nil, nil
+					return nil, unexpectedSource(p)
 				},
 			},
 			"node": &graphql.Field{
@@ -126,7 +153,7 @@ func init() {
 					if human, ok := p.Source.(*model.FriendsEdge); ok {
 						return human.Node, nil
 					}
-					return nil, nil
+					return nil, unexpectedSource(p)
 				},
 			},
 		},
@@ -137,23 +164,29 @@ func init() {
 		Description: "Information for paginating this connection",
 		Fields: graphql.Fields{
 			"startCursor": &graphql.Field{
-				Type:        graphql.NewNonNull(graphql.ID),
-				Description: "start cursor",
+				Type:        graphql.ID,
+				Description: "start cursor, null if the page is empty",
 				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
 					if pageInfo, ok := p.Source.(*model.PageInfo); ok {
-						return pageInfo.StartCursor, nil
+						if pageInfo.StartCursor == nil {
+							return nil, nil
+						}
+						return *pageInfo.StartCursor, nil
 					}
-					return nil, nil
+					return nil, unexpectedSource(p)
 				},
 			},
 			"endCursor": &graphql.Field{
-				Type:        graphql.NewNonNull(graphql.ID),
-				Description: "end cursor",
+				Type:        graphql.ID,
+				Description: "end cursor, null if the page is empty",
 				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
 					if pageInfo, ok := p.Source.(*model.PageInfo); ok {
-						return pageInfo.EndCursor, nil
+						if pageInfo.EndCursor == nil {
+							return nil, nil
+						}
+						return *pageInfo.EndCursor, nil
 					}
-					return nil, nil
+					return nil, unexpectedSource(p)
 				},
 			},
 			"hasNextPage": &graphql.Field{
@@ -163,7 +196,17 @@ func init() {
 					if pageInfo, ok := p.Source.(*model.PageInfo); ok {
 						return pageInfo.HasNextPage, nil
 					}
-					return nil, nil
+					return nil, unexpectedSource(p)
+				},
+			},
+			"hasPreviousPage": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.Boolean),
+				Description: "has previous page",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					if pageInfo, ok := p.Source.(*model.PageInfo); ok {
+						return pageInfo.HasPreviousPage, nil
+					}
+					return nil, unexpectedSource(p)
 				},
 			},
 		},
@@ -180,7 +223,7 @@ func init() {
 					if fc, ok := p.Source.(*model.FriendsConnection); ok {
 						return fc.TotalCount, nil
 					}
-					return 0, nil
+					return nil, unexpectedSource(p)
 				},
 			},
 			"edges": &graphql.Field{
@@ -190,7 +233,7 @@ func init() {
 					if fc, ok := p.Source.(*model.FriendsConnection); ok {
 						return fc.Edges, nil
 					}
-					return nil, nil
+					return nil, unexpectedSource(p)
 				},
 			},
 			"friends": &graphql.Field{
@@ -200,7 +243,7 @@ func init() {
 					if fc, ok := p.Source.(*model.FriendsConnection); ok {
 						return fc.Friends, nil
 					}
-					return nil, nil
+					return nil, unexpectedSource(p)
 				},
 			},
 			"pageInfo": &graphql.Field{
@@ -210,7 +253,7 @@ func init() {
 					if fc, ok := p.Source.(*model.FriendsConnection); ok {
 						return fc.PageInfo, nil
 					}
-					return nil, nil
+					return nil, unexpectedSource(p)
 				},
 			},
 		},
@@ -222,11 +265,19 @@ func init() {
 		Args: graphql.FieldConfigArgument{
 			"first": &graphql.ArgumentConfig{
 				Type:        graphql.Int,
-				Description: "Height in the preferred unit, default is meters",
+				Description: "Returns the first n friends",
 			},
 			"after": &graphql.ArgumentConfig{
 				Type:        graphql.ID,
-				Description: "Height in the preferred unit, default is meters",
+				Description: "Returns the friends after this cursor",
+			},
+			"last": &graphql.ArgumentConfig{
+				Type:        graphql.Int,
+				Description: "Returns the last n friends",
+			},
+			"before": &graphql.ArgumentConfig{
+				Type:        graphql.ID,
+				Description: "Returns the friends before this cursor",
 			},
 		},
 	})
@@ -240,9 +291,9 @@ func init() {
 				Description: "The ID of the starship",
 				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
 					if review, ok := p.Source.(*model.Starship); ok {
-						return review.ID, nil
+						return globalid.Encode("Starship", review.ID), nil
 					}
-					return nil, nil
+					return nil, unexpectedSource(p)
 				},
 			},
 			"name": &graphql.Field{
@@ -252,7 +303,7 @@ func init() {
 					if review, ok := p.Source.(*model.Starship); ok {
 						return review.Name, nil
 					}
-					return nil, nil
+					return nil, unexpectedSource(p)
 				},
 			},
 			"length": &graphql.Field{
@@ -261,7 +312,7 @@ func init() {
 				Args: graphql.FieldConfigArgument{
 					"unit": &graphql.ArgumentConfig{
 						Type:        lengthUnitEnum,
-						Description: "Height in the preferred unit, default is meters",
+						Description: "Length in the preferred unit, default is meters",
 					},
 				},
 				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
@@ -273,7 +324,7 @@ func init() {
 						}
 						return starship.Length, nil
 					}
-					return 0, nil
+					return nil, unexpectedSource(p)
 				},
 			},
 			"history": &graphql.Field{
@@ -283,10 +334,13 @@ func init() {
 					if review, ok := p.Source.(*model.Starship); ok {
 						return review.History, nil
 					}
-					return nil, nil
+					return nil, unexpectedSource(p)
 				},
 			},
 		},
+		Interfaces: []*graphql.Interface{
+			nodeInterface,
+		},
 	})
 
 	humanType = graphql.NewObject(graphql.ObjectConfig{
@@ -298,9 +352,9 @@ func init() {
 				Description: "The id of the human.",
 				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
 					if human, ok := p.Source.(*model.Human); ok {
-						return human.ID, nil
+						return globalid.Encode("Human", human.ID), nil
 					}
-					return nil, nil
+					return nil, unexpectedSource(p)
 				},
 			},
 			"name": &graphql.Field{
@@ -310,7 +364,7 @@ func init() {
 					if human, ok := p.Source.(*model.Human); ok {
 						return human.Name, nil
 					}
-					return nil, nil
+					return nil, unexpectedSource(p)
 				},
 			},
 			"height": &graphql.Field{
@@ -331,17 +385,17 @@ func init() {
 						}
 						return human.Height, nil
 					}
-					return nil, nil
+					return nil, unexpectedSource(p)
 				},
 			},
 			"mass": &graphql.Field{
 				Type:        graphql.Float,
 				Description: "Mass in kilograms, or null if unknown",
 				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
-					if human, ok := p.Source.(*model.Human); !ok {
+					if human, ok := p.Source.(*model.Human); ok {
 						return human.Mass, nil
 					}
-					return nil, nil
+					return nil, unexpectedSource(p)
 				},
 			},
 			"friends": &graphql.Field{
@@ -351,7 +405,7 @@ func init() {
 					if human, ok := p.Source.(*model.Human); ok {
 						return human.Friends, nil
 					}
-					return []interface{}{}, nil
+					return nil, unexpectedSource(p)
 				},
 			},
 			"friendsConnection": &graphql.Field{
@@ -360,11 +414,19 @@ func init() {
 				Args: graphql.FieldConfigArgument{
 					"first": &graphql.ArgumentConfig{
 						Type:        graphql.Int,
-						Description: "Height in the preferred unit, default is meters",
+						Description: "Returns the first n friends",
 					},
 					"after": &graphql.ArgumentConfig{
 						Type:        graphql.ID,
-						Description: "Height in the preferred unit, default is meters",
+						Description: "Returns the friends after this cursor",
+					},
+					"last": &graphql.ArgumentConfig{
+						Type:        graphql.Int,
+						Description: "Returns the last n friends",
+					},
+					"before": &graphql.ArgumentConfig{
+						Type:        graphql.ID,
+						Description: "Returns the friends before this cursor",
 					},
 				},
 				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
@@ -378,7 +440,7 @@ func init() {
 					if human, ok := p.Source.(*model.Human); ok {
 						return human.AppearsIn, nil
 					}
-					return nil, nil
+					return nil, unexpectedSource(p)
 				},
 			},
 			"starships": &graphql.Field{
@@ -388,12 +450,13 @@ func init() {
 					if human, ok := p.Source.(*model.Human); ok {
 						return human.Starships, nil
 					}
-					return nil, nil
+					return nil, unexpectedSource(p)
 				},
 			},
 		},
 		Interfaces: []*graphql.Interface{
 			characterInterface,
+			nodeInterface,
 		},
 	})
 
@@ -406,9 +469,9 @@ func init() {
 				Description: "The id of the droid.",
 				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
 					if droid, ok := p.Source.(*model.Droid); ok {
-						return droid.ID, nil
+						return globalid.Encode("Droid", droid.ID), nil
 					}
-					return nil, nil
+					return nil, unexpectedSource(p)
 				},
 			},
 			"name": &graphql.Field{
@@ -418,7 +481,7 @@ func init() {
 					if droid, ok := p.Source.(*model.Droid); ok {
 						return droid.Name, nil
 					}
-					return nil, nil
+					return nil, unexpectedSource(p)
 				},
 			},
 			"friends": &graphql.Field{
@@ -428,7 +491,7 @@ func init() {
 					if droid, ok := p.Source.(*model.Droid); ok {
 						return droid.Friends, nil
 					}
-					return []interface{}{}, nil
+					return nil, unexpectedSource(p)
 				},
 			},
 			"friendsConnection": &graphql.Field{
@@ -437,11 +500,19 @@ func init() {
 				Args: graphql.FieldConfigArgument{
 					"first": &graphql.ArgumentConfig{
 						Type:        graphql.Int,
-						Description: "Height in the preferred unit, default is meters",
+						Description: "Returns the first n friends",
 					},
 					"after": &graphql.ArgumentConfig{
 						Type:        graphql.ID,
-						Description: "Height in the preferred unit, default is meters",
+						Description: "Returns the friends after this cursor",
+					},
+					"last": &graphql.ArgumentConfig{
+						Type:        graphql.Int,
+						Description: "Returns the last n friends",
+					},
+					"before": &graphql.ArgumentConfig{
+						Type:        graphql.ID,
+						Description: "Returns the friends before this cursor",
 					},
 				},
 				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
@@ -455,7 +526,7 @@ func init() {
 					if droid, ok := p.Source.(*model.Droid); ok {
 						return droid.AppearsIn, nil
 					}
-					return nil, nil
+					return nil, unexpectedSource(p)
 				},
 			},
 			"primaryFunction": &graphql.Field{
@@ -465,12 +536,13 @@ func init() {
 					if droid, ok := p.Source.(*model.Droid); ok {
 						return droid.PrimaryFunction, nil
 					}
-					return nil, nil
+					return nil, unexpectedSource(p)
 				},
 			},
 		},
 		Interfaces: []*graphql.Interface{
 			characterInterface,
+			nodeInterface,
 		},
 	})
 
@@ -507,7 +579,7 @@ func init() {
 					if review, ok := p.Source.(*model.Review); ok {
 						return review.Stars, nil
 					}
-					return 0, nil
+					return nil, unexpectedSource(p)
 				},
 			},
 			"commentary": &graphql.Field{
@@ -517,7 +589,7 @@ func init() {
 					if review, ok := p.Source.(*model.Review); ok {
 						return review.Commentary, nil
 					}
-					return nil, nil
+					return nil, unexpectedSource(p)
 				},
 			},
 			"time": &graphql.Field{
@@ -527,7 +599,7 @@ func init() {
 					if review, ok := p.Source.(*model.Review); ok {
 						return review.Time, nil
 					}
-					return nil, nil
+					return nil, unexpectedSource(p)
 				},
 			},
 		},
@@ -583,26 +655,29 @@ func init() {
 						Description: "The episodes in the Star Wars trilogy",
 					},
 					"since": &graphql.ArgumentConfig{
-						Type:        graphql.NewNonNull(graphql.DateTime),
+						Type:        graphql.DateTime,
 						Description: "when the review was posted",
 					},
 				},
 				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
 					episode, ok := p.Args["episode"].(model.Episode)
 					if !ok {
-						return nil, nil
+						return nil, unexpectedArg(p, "episode")
 					}
-					since, ok := p.Args["since"].(time.Time)
-					if !ok {
-						return nil, nil
+					reviews, err := data.Reviews.List(episode)
+					if err != nil {
+						return nil, err
+					}
+					if p.Args["since"] == nil {
+						return reviews, nil
 					}
-					review, ok := data.Reviews[episode]
+					since, ok := p.Args["since"].(time.Time)
 					if !ok {
-						return nil, nil
+						return nil, unexpectedArg(p, "since")
 					}
 					var filtered []*model.Review
-					for _, r := range review {
-						if r.Time.After(since) {
+					for _, r := range reviews {
+						if r.Time != nil && r.Time.After(since) {
 							filtered = append(filtered, r)
 						}
 					}
@@ -648,14 +723,18 @@ func init() {
 				},
 				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
 					if id, ok := p.Args["id"].(string); ok {
-						if h, ok := data.Humans[id]; ok {
+						key, err := globalid.Key(id, "Human", "Droid")
+						if err != nil {
+							return nil, err
+						}
+						if h, ok := data.Humans[key]; ok {
 							return h, nil
 						}
-						if d, ok := data.Droids[id]; ok {
+						if d, ok := data.Droids[key]; ok {
 							return d, nil
 						}
 					}
-					return nil, nil
+					return nil, apperr.NotFoundf("character %q not found", p.Args["id"])
 				},
 			},
 			"starship": &graphql.Field{
@@ -668,11 +747,15 @@ func init() {
 				},
 				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
 					if id, ok := p.Args["id"].(string); ok {
-						if s, ok := data.Starships[id]; ok {
+						key, err := globalid.Key(id, "Starship")
+						if err != nil {
+							return nil, err
+						}
+						if s, ok := data.Starships[key]; ok {
 							return s, nil
 						}
 					}
-					return nil, nil
+					return nil, apperr.NotFoundf("starship %q not found", p.Args["id"])
 				},
 			},
 			"human": &graphql.Field{
@@ -685,9 +768,15 @@ func init() {
 				},
 				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
 					if id, ok := p.Args["id"].(string); ok {
-						return data.Humans[id], nil
+						key, err := globalid.Key(id, "Human")
+						if err != nil {
+							return nil, err
+						}
+						if h, ok := data.Humans[key]; ok {
+							return h, nil
+						}
 					}
-					return nil, nil
+					return nil, apperr.NotFoundf("human %q not found", p.Args["id"])
 				},
 			},
 			"droid": &graphql.Field{
@@ -700,9 +789,52 @@ func init() {
 				},
 				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
 					if id, ok := p.Args["id"].(string); ok {
-						return data.Droids[id], nil
+						key, err := globalid.Key(id, "Droid")
+						if err != nil {
+							return nil, err
+						}
+						if d, ok := data.Droids[key]; ok {
+							return d, nil
+						}
+					}
+					return nil, apperr.NotFoundf("droid %q not found", p.Args["id"])
+				},
+			},
+			"node": &graphql.Field{
+				Type:        nodeInterface,
+				Description: "Fetches an object given its global ID",
+				Args: graphql.FieldConfigArgument{
+					"id": &graphql.ArgumentConfig{
+						Description: "The global ID of the object",
+						Type:        graphql.NewNonNull(graphql.ID),
+					},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					id, _ := p.Args["id"].(string)
+					return resolveNode(id)
+				},
+			},
+			"nodes": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.NewList(nodeInterface)),
+				Description: "Fetches objects given their global IDs",
+				Args: graphql.FieldConfigArgument{
+					"ids": &graphql.ArgumentConfig{
+						Description: "The global IDs of the objects",
+						Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(graphql.ID))),
+					},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					ids, _ := p.Args["ids"].([]interface{})
+					nodes := make([]interface{}, len(ids))
+					for i, id := range ids {
+						s, _ := id.(string)
+						node, err := resolveNode(s)
+						if err != nil {
+							return nil, err
+						}
+						nodes[i] = node
 					}
-					return nil, nil
+					return nodes, nil
 				},
 			},
 		},
@@ -724,11 +856,11 @@ func init() {
 				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
 					episode, ok := p.Args["episode"].(model.Episode)
 					if !ok {
-						return nil, nil
+						return nil, unexpectedArg(p, "episode")
 					}
 					input, ok := p.Args["review"].(map[string]interface{})
 					if !ok {
-						return nil, nil
+						return nil, apperr.BadUserInputf("review is required")
 					}
 					review := &model.Review{
 						Stars: input["stars"].(int),
@@ -741,34 +873,74 @@ func init() {
 							review.Time = &t
 						}
 					}
-					data.Reviews[episode] = append(data.Reviews[episode], review)
+					if err := data.Reviews.Add(episode, review); err != nil {
+						return nil, err
+					}
 					return review, nil
 				},
 			},
 		},
 	})
 
-	StarWarsSchema, _ = graphql.NewSchema(graphql.SchemaConfig{
+	StarWarsSchemaConfig = graphql.SchemaConfig{
 		Query:    queryType,
 		Mutation: mutationType,
-	})
+	}
+	StarWarsSchema, _ = graphql.NewSchema(StarWarsSchemaConfig)
 
 }
 
-func resolveFriendConnection(p graphql.ResolveParams) (interface{}, error) {
-	limit := 0
-	if p.Args["first"] != nil {
-		if t, ok := p.Args["first"].(int); ok {
-			limit = t
+// unexpectedSource reports a field resolved on a value of the wrong type, which
+// is a bug in the schema rather than in the request.
+func unexpectedSource(p graphql.ResolveParams) error {
+	return apperr.Internalf("%s.%s resolved on unexpected %T", p.Info.ParentType.Name(), p.Info.FieldName, p.Source)
+}
+
+// unexpectedArg reports an argument of the wrong type, which the schema
+// should have rejected before the resolver ran.
+func unexpectedArg(p graphql.ResolveParams, name string) error {
+	return apperr.Internalf("%s.%s got unexpected %T for argument %s", p.Info.ParentType.Name(), p.Info.FieldName, p.Args[name], name)
+}
+
+// resolveNode looks up the object identified by a global ID. Unknown objects
+// resolve to null, while malformed IDs are reported as errors.
+func resolveNode(id string) (interface{}, error) {
+	typeName, key, err := globalid.Decode(id)
+	if err != nil {
+		return nil, err
+	}
+	switch typeName {
+	case "Human":
+		if h, ok := data.Humans[key]; ok {
+			return h, nil
+		}
+	case "Droid":
+		if d, ok := data.Droids[key]; ok {
+			return d, nil
+		}
+	case "Starship":
+		if s, ok := data.Starships[key]; ok {
+			return s, nil
 		}
+	default:
+		return nil, apperr.BadUserInputf("unknown type %q in global id %q", typeName, id)
 	}
+	return nil, nil
+}
 
-	from := 0
-	if p.Args["after"] != nil {
-		if t, ok := p.Args["after"].(string); ok {
-			cursor, _ := decodeCursor(t)
-			from = cursor
-		}
+func resolveFriendConnection(p graphql.ResolveParams) (interface{}, error) {
+	var args pagination.Args
+	if first, ok := p.Args["first"].(int); ok {
+		args.First = &first
+	}
+	if after, ok := p.Args["after"].(string); ok {
+		args.After = &after
+	}
+	if last, ok := p.Args["last"].(int); ok {
+		args.Last = &last
+	}
+	if before, ok := p.Args["before"].(string); ok {
+		args.Before = &before
 	}
 
 	var ids []model.Character
@@ -779,53 +951,32 @@ func resolveFriendConnection(p graphql.ResolveParams) (interface{}, error) {
 		ids = append(ids, droid.Friends...)
 	}
 
-	if from >= len(ids) {
-		return &model.FriendsConnection{
-			TotalCount: len(ids),
-			PageInfo:   &model.PageInfo{HasNextPage: false},
-		}, nil
+	window, err := pagination.Slice(len(ids), args)
+	if err != nil {
+		return nil, err
 	}
 
-	to := len(ids)
-	if limit != 0 {
-		to = from + limit
-		if to > len(ids) {
-			to = len(ids)
-		}
-	}
 	var edges []*model.FriendsEdge
-	for i := from; i < to && i < len(ids); i++ {
+	for i := window.From; i < window.To; i++ {
 		edge := &model.FriendsEdge{
-			Cursor: encodeCursor(i + 1),
+			Cursor: pagination.EncodeCursor(i),
 			Node:   ids[i],
 		}
 		edges = append(edges, edge)
 	}
+	pageInfo := &model.PageInfo{
+		HasNextPage:     window.HasNextPage,
+		HasPreviousPage: window.HasPreviousPage,
+	}
+	if window.To > window.From {
+		start, end := pagination.EncodeCursor(window.From), pagination.EncodeCursor(window.To-1)
+		pageInfo.StartCursor, pageInfo.EndCursor = &start, &end
+	}
 	friendsConnection := &model.FriendsConnection{
 		TotalCount: len(ids),
-		Friends:    ids[from:to],
-		PageInfo: &model.PageInfo{
-			StartCursor: encodeCursor(from + 1),
-			EndCursor:   encodeCursor(to + 1),
-			HasNextPage: to >= len(ids),
-		},
-		Edges: edges,
+		Friends:    ids[window.From:window.To],
+		PageInfo:   pageInfo,
+		Edges:      edges,
 	}
 	return friendsConnection, nil
 }
-
-func decodeCursor(s string) (int, error) {
-	b, err := base64.StdEncoding.DecodeString(s)
-	if err != nil {
-		return 0, err
-	}
-	i, err := strconv.Atoi(strings.TrimPrefix(string(b), "cursor"))
-	if err != nil {
-		return 0, err
-	}
-	return i, err
-}
-
-func encodeCursor(i int) string {
-	return base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf("cursor%d", i)))
-}
@@ -1,20 +1,74 @@
 package main
 
+//go:generate go run ./printschema -o schema.graphql
+
 import (
+	"flag"
 	"fmt"
+	"graphql/apperr"
+	"graphql/dataset"
+	"graphql/explorer"
+	"graphql/graphql-starwar/data"
 	"graphql/graphql-starwar/exec"
+	"graphql/graphql-starwar/tracing"
+	"graphql/metrics"
+	"graphql/querylimit"
+	"graphql/sdl"
+	"log"
 	"net/http"
 
+	"github.com/graphql-go/graphql"
 	"github.com/graphql-go/handler"
 )
 
+var (
+	datasetPath = flag.String("dataset", "", "JSON or YAML fixture of the Star Wars data, the embedded one if empty")
+	reviewLog   = flag.String("reviews", "", "append-only log file for reviews, replacing those of the dataset, kept in memory if empty")
+	limits      = querylimit.Flags()
+	explore     = explorer.Flags("Star Wars", "/")
+	traceAll    = flag.Bool("tracing", false, "add Apollo tracing to every response, not only to those of requests with the "+tracing.Header+" header")
+)
+
 func main() {
-	http.Handle("/", handler.New(&handler.Config{
-		Schema:     &exec.StarWarsSchema,
-		Pretty:     true,
-		GraphiQL:   true,
-		Playground: true,
-	}))
-	err := http.ListenAndServe(":8080", nil)
+	flag.Parse()
+
+	ds, err := dataset.Open(*datasetPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+	data.Load(ds)
+
+	if *reviewLog != "" {
+		store, err := data.OpenFileReviewStore(*reviewLog)
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer store.Close()
+		data.Reviews = store
+	}
+
+	m := metrics.New()
+	limits.GraphQLGo(&exec.StarWarsSchema)
+	exec.StarWarsSchema.AddExtensions(metrics.GraphQLGoExtension{Metrics: m})
+	// The traced schema is another schema of the same types, so that only
+	// the requests asking for it are traced.
+	traced, err := graphql.NewSchema(exec.StarWarsSchemaConfig)
+	if err != nil {
+		log.Fatal(err)
+	}
+	limits.GraphQLGo(&traced)
+	traced.AddExtensions(metrics.GraphQLGoExtension{Metrics: m}, tracing.Extension{})
+	newHandler := func(schema *graphql.Schema) http.Handler {
+		return handler.New(&handler.Config{
+			Schema:        schema,
+			Pretty:        true,
+			FormatErrorFn: apperr.FormatError,
+		})
+	}
+	http.Handle("/", m.Middleware(tracing.Handler(*traceAll, newHandler(&traced), newHandler(&exec.StarWarsSchema))))
+	http.Handle("/metrics", m)
+	http.Handle("/schema.graphql", sdl.Handler(&exec.StarWarsSchema))
+	http.Handle("/explorer/", http.StripPrefix("/explorer", explorer.Handler(*explore)))
+	err = http.ListenAndServe(":8080", nil)
 	fmt.Println(err)
 }
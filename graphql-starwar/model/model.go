@@ -50,10 +50,13 @@ type Human struct {
 func (Human) isCharacter()    {}
 func (Human) IsSearchResult() {}
 
+// PageInfo describes a page of a connection. Its cursors are nil if the page
+// is empty.
 type PageInfo struct {
-	StartCursor string `json:"startCursor"`
-	EndCursor   string `json:"endCursor"`
-	HasNextPage bool   `json:"hasNextPage"`
+	StartCursor     *string `json:"startCursor"`
+	EndCursor       *string `json:"endCursor"`
+	HasNextPage     bool    `json:"hasNextPage"`
+	HasPreviousPage bool    `json:"hasPreviousPage"`
 }
 
 type Review struct {
@@ -0,0 +1,30 @@
+// Command printschema writes the SDL of the Star Wars schema built in
+// graphql-starwar/exec, so that it can be diffed against the schemas of the
+// other servers. Run from the root of the module:
+//
+//	go run ./graphql-starwar/printschema
+package main
+
+import (
+	"flag"
+	"graphql/graphql-starwar/exec"
+	"graphql/sdl"
+	"io/ioutil"
+	"log"
+	"os"
+)
+
+var output = flag.String("o", "graphql-starwar/schema.graphql", "file written, the standard output if -")
+
+func main() {
+	flag.Parse()
+
+	schema := []byte(sdl.Print(&exec.StarWarsSchema))
+	if *output == "-" {
+		os.Stdout.Write(schema)
+		return
+	}
+	if err := ioutil.WriteFile(*output, schema, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,149 @@
+// Package tracing reports how long graphql-starwar takes to parse, validate
+// and resolve every field of a request, in the Apollo tracing format under
+// extensions.tracing, the format the gqlgen server reports with its
+// apollotracing extension.
+package tracing
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+)
+
+// Header asks for the trace of a request when not every request is traced.
+const Header = "Apollo-Tracing"
+
+// Handler serves the requests to trace with traced, a handler of a schema
+// with the Extension, and the others with plain, so that their responses
+// are left without extensions. A request is traced if all is set or it has
+// the Header, with a value other than 0 or false.
+func Handler(all bool, traced, plain http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if all {
+			traced.ServeHTTP(w, r)
+			return
+		}
+		switch r.Header.Get(Header) {
+		case "", "0", "false":
+			plain.ServeHTTP(w, r)
+		default:
+			traced.ServeHTTP(w, r)
+		}
+	})
+}
+
+// Trace is the Apollo tracing data of a request.
+type Trace struct {
+	Version    int       `json:"version"`
+	StartTime  time.Time `json:"startTime"`
+	EndTime    time.Time `json:"endTime"`
+	Duration   int64     `json:"duration"`
+	Parsing    Phase     `json:"parsing"`
+	Validation Phase     `json:"validation"`
+	Execution  struct {
+		Resolvers []*Resolver `json:"resolvers"`
+	} `json:"execution"`
+
+	mu sync.Mutex
+}
+
+// Phase is a step of a request. Offsets and durations are in nanoseconds,
+// offsets from the start of the request.
+type Phase struct {
+	StartOffset int64 `json:"startOffset"`
+	Duration    int64 `json:"duration"`
+}
+
+// Resolver is the resolution of a field.
+type Resolver struct {
+	Path        []interface{} `json:"path"`
+	ParentType  string        `json:"parentType"`
+	FieldName   string        `json:"fieldName"`
+	ReturnType  string        `json:"returnType"`
+	StartOffset int64         `json:"startOffset"`
+	Duration    int64         `json:"duration"`
+}
+
+type contextKey struct{}
+
+// Extension traces the requests of a graphql-go schema, added with
+// AddExtensions. graphql-go calls it around the Resolve function of every
+// field, including those resolved by the default resolver.
+type Extension struct{}
+
+var _ graphql.Extension = Extension{}
+
+func (Extension) Init(ctx context.Context, p *graphql.Params) context.Context {
+	return context.WithValue(ctx, contextKey{}, &Trace{Version: 1, StartTime: time.Now()})
+}
+
+func (Extension) Name() string {
+	return "tracing"
+}
+
+func (Extension) ParseDidStart(ctx context.Context) (context.Context, graphql.ParseFinishFunc) {
+	t, offset := start(ctx)
+	return ctx, func(error) { t.end(&t.Parsing, offset) }
+}
+
+func (Extension) ValidationDidStart(ctx context.Context) (context.Context, graphql.ValidationFinishFunc) {
+	t, offset := start(ctx)
+	return ctx, func([]gqlerrors.FormattedError) { t.end(&t.Validation, offset) }
+}
+
+func (Extension) ExecutionDidStart(ctx context.Context) (context.Context, graphql.ExecutionFinishFunc) {
+	return ctx, func(*graphql.Result) {}
+}
+
+func (Extension) ResolveFieldDidStart(ctx context.Context, info *graphql.ResolveInfo) (context.Context, graphql.ResolveFieldFinishFunc) {
+	t, offset := start(ctx)
+	r := &Resolver{
+		Path:        info.Path.AsArray(),
+		ParentType:  info.ParentType.Name(),
+		FieldName:   info.FieldName,
+		ReturnType:  info.ReturnType.String(),
+		StartOffset: offset,
+	}
+	return ctx, func(interface{}, error) {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+		r.Duration = t.offset() - offset
+		t.Execution.Resolvers = append(t.Execution.Resolvers, r)
+	}
+}
+
+func (Extension) HasResult() bool {
+	return true
+}
+
+// GetResult returns the Trace of the request, ending it.
+func (Extension) GetResult(ctx context.Context) interface{} {
+	t := ctx.Value(contextKey{}).(*Trace)
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.EndTime = time.Now()
+	t.Duration = t.EndTime.Sub(t.StartTime).Nanoseconds()
+	return t
+}
+
+// start returns the trace of ctx and the offset of now.
+func start(ctx context.Context) (*Trace, int64) {
+	t := ctx.Value(contextKey{}).(*Trace)
+	return t, t.offset()
+}
+
+// offset returns the time elapsed since the start of the request.
+func (t *Trace) offset() int64 {
+	return time.Since(t.StartTime).Nanoseconds()
+}
+
+// end sets phase to the step that started at offset and ends now.
+func (t *Trace) end(phase *Phase, offset int64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	*phase = Phase{StartOffset: offset, Duration: t.offset() - offset}
+}
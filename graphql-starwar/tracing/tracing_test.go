@@ -0,0 +1,97 @@
+package tracing
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+)
+
+func TestHandler(t *testing.T) {
+	tests := []struct {
+		all    bool
+		header string
+		traced bool
+	}{
+		{false, "", false},
+		{false, "0", false},
+		{false, "false", false},
+		{false, "1", true},
+		{false, "true", true},
+		{true, "", true},
+		{true, "false", true},
+	}
+	for _, test := range tests {
+		served := ""
+		handler := func(name string) http.Handler {
+			return http.HandlerFunc(func(http.ResponseWriter, *http.Request) { served = name })
+		}
+		r := httptest.NewRequest(http.MethodPost, "/graphql", nil)
+		if test.header != "" {
+			r.Header.Set(Header, test.header)
+		}
+		Handler(test.all, handler("traced"), handler("plain")).ServeHTTP(httptest.NewRecorder(), r)
+		if got := served == "traced"; got != test.traced {
+			t.Errorf("all %v and header %q: got %s, want traced %v", test.all, test.header, served, test.traced)
+		}
+	}
+}
+
+func TestExtension(t *testing.T) {
+	droid := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Droid",
+		Fields: graphql.Fields{
+			"name": &graphql.Field{Type: graphql.String},
+		},
+	})
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"droids": &graphql.Field{
+					Type: graphql.NewList(droid),
+					Resolve: func(graphql.ResolveParams) (interface{}, error) {
+						return []map[string]interface{}{{"name": "R2-D2"}, {"name": "C-3PO"}}, nil
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	schema.AddExtensions(Extension{})
+
+	result := graphql.Do(graphql.Params{Schema: schema, RequestString: `{ droids { name } }`, Context: context.Background()})
+	if len(result.Errors) > 0 {
+		t.Fatal(result.Errors)
+	}
+	trace, ok := result.Extensions["tracing"].(*Trace)
+	if !ok {
+		t.Fatalf("got extensions %v, want a trace", result.Extensions)
+	}
+	if trace.Version != 1 || trace.Duration < 0 || trace.EndTime.Before(trace.StartTime) {
+		t.Errorf("got trace version %d of %dns from %v to %v", trace.Version, trace.Duration, trace.StartTime, trace.EndTime)
+	}
+	if trace.Parsing.Duration < 0 || trace.Validation.StartOffset < trace.Parsing.StartOffset+trace.Parsing.Duration {
+		t.Errorf("got parsing %+v and validation %+v, want validation after parsing", trace.Parsing, trace.Validation)
+	}
+
+	var paths [][]interface{}
+	for _, r := range trace.Execution.Resolvers {
+		paths = append(paths, r.Path)
+		if r.StartOffset < trace.Validation.StartOffset || r.StartOffset+r.Duration > trace.Duration {
+			t.Errorf("got resolver %+v outside of execution", r)
+		}
+	}
+	want := [][]interface{}{{"droids"}, {"droids", 0, "name"}, {"droids", 1, "name"}}
+	if !reflect.DeepEqual(paths, want) {
+		t.Errorf("got resolvers at %v, want %v", paths, want)
+	}
+	if r := trace.Execution.Resolvers[0]; r.ParentType != "Query" || r.FieldName != "droids" || r.ReturnType != "[Droid]" {
+		t.Errorf("got resolver %+v, want Query.droids of [Droid]", r)
+	}
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"fmt"
+	"graphql/metrics"
 	"log"
 	"net/http"
 
@@ -143,12 +144,15 @@ func data() graphql.Schema {
 
 func testDemo() {
 	schema := data()
-	http.Handle("/", handler.New(&handler.Config{
+	m := metrics.New()
+	schema.AddExtensions(metrics.GraphQLGoExtension{Metrics: m})
+	http.Handle("/", m.Middleware(handler.New(&handler.Config{
 		Schema:     &schema,
 		Pretty:     true,
 		GraphiQL:   true,
 		Playground: true,
-	}))
+	})))
+	http.Handle("/metrics", m)
 	err := http.ListenAndServe(":8080", nil)
 	fmt.Println(err)
 }
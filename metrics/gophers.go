@@ -0,0 +1,43 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/graph-gophers/graphql-go/errors"
+	"github.com/graph-gophers/graphql-go/introspection"
+	"github.com/graph-gophers/graphql-go/trace"
+)
+
+// Tracer names the operation a graph-gophers schema executes for the
+// Middleware, and times its resolvers. It is set with the graphql.Tracer
+// schema option, and replaces the OpenTracing tracer the schema uses by
+// default.
+type Tracer struct {
+	Metrics *Metrics
+}
+
+var _ trace.Tracer = Tracer{}
+
+func (t Tracer) TraceQuery(ctx context.Context, queryString string, operationName string, variables map[string]interface{}, varTypes map[string]*introspection.Type) (context.Context, trace.TraceQueryFinishFunc) {
+	// The request itself is recorded by the Middleware.
+	return executing(ctx, queryString, operationName), func([]*errors.QueryError) {}
+}
+
+func (t Tracer) TraceField(ctx context.Context, label, typeName, fieldName string, trivial bool, args map[string]interface{}) (context.Context, trace.TraceFieldFinishFunc) {
+	// graph-gophers resolves the selections of a field in the context
+	// returned here, so the path is carried down through it.
+	path := fieldName
+	if parent, ok := ctx.Value(pathKey).(string); ok {
+		path = parent + "." + fieldName
+	}
+	ctx = context.WithValue(ctx, pathKey, path)
+	if trivial {
+		return ctx, func(*errors.QueryError) {}
+	}
+	start := time.Now()
+	return ctx, func(*errors.QueryError) {
+		operation, _ := operationOf(ctx)
+		t.Metrics.ObserveResolver(operation, path, time.Since(start))
+	}
+}
@@ -0,0 +1,87 @@
+package metrics
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// Extension records the requests and resolvers of a gqlgen handler. The
+// events of a subscription are not counted as requests.
+type Extension struct {
+	Metrics *Metrics
+}
+
+var _ interface {
+	graphql.HandlerExtension
+	graphql.ResponseInterceptor
+	graphql.FieldInterceptor
+} = Extension{}
+
+func (e Extension) ExtensionName() string {
+	return "Metrics"
+}
+
+func (e Extension) Validate(schema graphql.ExecutableSchema) error {
+	return nil
+}
+
+func (e Extension) InterceptResponse(ctx context.Context, next graphql.ResponseHandler) *graphql.Response {
+	resp := next(ctx)
+	if !graphql.HasOperationContext(ctx) {
+		return resp
+	}
+	rc := graphql.GetOperationContext(ctx)
+	if rc.Operation != nil && rc.Operation.Operation == ast.Subscription {
+		return resp
+	}
+	var codes []string
+	if resp != nil {
+		for _, err := range resp.Errors {
+			code, _ := err.Extensions["code"].(string)
+			if code == "" {
+				code = noCode
+			}
+			codes = append(codes, code)
+		}
+	}
+	e.Metrics.ObserveRequest(requestOperation(rc), time.Since(rc.Stats.OperationStart), codes)
+	return resp
+}
+
+func (e Extension) InterceptField(ctx context.Context, next graphql.Resolver) (interface{}, error) {
+	fc := graphql.GetFieldContext(ctx)
+	if !fc.IsMethod && !fc.IsResolver {
+		return next(ctx)
+	}
+	start := time.Now()
+	res, err := next(ctx)
+	e.Metrics.ObserveResolver(requestOperation(graphql.GetOperationContext(ctx)), fieldContextPath(fc), time.Since(start))
+	return res, err
+}
+
+// requestOperation returns the name of the operation executed, which
+// gqlgen only knows from the request for named operations.
+func requestOperation(rc *graphql.OperationContext) string {
+	if rc.Operation != nil && rc.Operation.Name != "" {
+		return rc.Operation.Name
+	}
+	return rc.OperationName
+}
+
+// fieldContextPath returns the path of the field of fc by field names.
+func fieldContextPath(fc *graphql.FieldContext) string {
+	var names []string
+	for ; fc != nil; fc = fc.Parent {
+		if fc.Index == nil && fc.Field.Field != nil {
+			names = append(names, fc.Field.Name)
+		}
+	}
+	for i, j := 0, len(names)-1; i < j; i, j = i+1, j-1 {
+		names[i], names[j] = names[j], names[i]
+	}
+	return strings.Join(names, ".")
+}
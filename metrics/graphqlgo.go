@@ -0,0 +1,123 @@
+package metrics
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// GraphQLGoExtension names the operation a graphql-go schema executes for
+// the Middleware, and times its resolvers. It is added with AddExtensions.
+// Fields without a Resolve function are not timed.
+type GraphQLGoExtension struct {
+	Metrics *Metrics
+}
+
+var _ graphql.Extension = GraphQLGoExtension{}
+
+func (e GraphQLGoExtension) Init(ctx context.Context, p *graphql.Params) context.Context {
+	return executing(ctx, p.RequestString, p.OperationName)
+}
+
+func (e GraphQLGoExtension) Name() string {
+	return "Metrics"
+}
+
+func (e GraphQLGoExtension) ParseDidStart(ctx context.Context) (context.Context, graphql.ParseFinishFunc) {
+	return ctx, func(error) {}
+}
+
+func (e GraphQLGoExtension) ValidationDidStart(ctx context.Context) (context.Context, graphql.ValidationFinishFunc) {
+	return ctx, func([]gqlerrors.FormattedError) {}
+}
+
+func (e GraphQLGoExtension) ExecutionDidStart(ctx context.Context) (context.Context, graphql.ExecutionFinishFunc) {
+	return ctx, func(*graphql.Result) {}
+}
+
+func (e GraphQLGoExtension) ResolveFieldDidStart(ctx context.Context, info *graphql.ResolveInfo) (context.Context, graphql.ResolveFieldFinishFunc) {
+	parent, ok := info.ParentType.(*graphql.Object)
+	if !ok {
+		return ctx, func(interface{}, error) {}
+	}
+	if field := parent.Fields()[info.FieldName]; field == nil || field.Resolve == nil {
+		return ctx, func(interface{}, error) {}
+	}
+	start := time.Now()
+	return ctx, func(interface{}, error) {
+		operation, _ := operationOf(ctx)
+		e.Metrics.ObserveResolver(operation, fieldPath(info), time.Since(start))
+	}
+}
+
+func (e GraphQLGoExtension) HasResult() bool {
+	return false
+}
+
+func (e GraphQLGoExtension) GetResult(context.Context) interface{} {
+	return nil
+}
+
+// fieldPath returns the path of the field resolved. The path graphql-go
+// gives holds the response keys, which may be aliases, so the field names
+// are looked up in the selections of the operation.
+func fieldPath(info *graphql.ResolveInfo) string {
+	var keys []string
+	for p := info.Path; p != nil; p = p.Prev {
+		if key, ok := p.Key.(string); ok {
+			keys = append(keys, key)
+		}
+	}
+	var sets []*ast.SelectionSet
+	if op, ok := info.Operation.(*ast.OperationDefinition); ok {
+		sets = append(sets, op.SelectionSet)
+	}
+	names := make([]string, len(keys))
+	for i := range keys {
+		key := keys[len(keys)-1-i]
+		names[i], sets = field(sets, key, info.Fragments)
+	}
+	names[len(names)-1] = info.FieldName
+	return strings.Join(names, ".")
+}
+
+// field returns the name of the field selected as key in sets and the
+// selection sets of its subfields. A key selected several times, through
+// fragments for instance, has its subfields spread over several sets.
+func field(sets []*ast.SelectionSet, key string, fragments map[string]ast.Definition) (string, []*ast.SelectionSet) {
+	name := key
+	var children []*ast.SelectionSet
+	var visit func(set *ast.SelectionSet)
+	visit = func(set *ast.SelectionSet) {
+		if set == nil {
+			return
+		}
+		for _, sel := range set.Selections {
+			switch sel := sel.(type) {
+			case *ast.Field:
+				responseKey := sel.Name.Value
+				if sel.Alias != nil {
+					responseKey = sel.Alias.Value
+				}
+				if responseKey == key {
+					name = sel.Name.Value
+					children = append(children, sel.SelectionSet)
+				}
+			case *ast.InlineFragment:
+				visit(sel.SelectionSet)
+			case *ast.FragmentSpread:
+				if def, ok := fragments[sel.Name.Value].(*ast.FragmentDefinition); ok {
+					visit(def.SelectionSet)
+				}
+			}
+		}
+	}
+	for _, set := range sets {
+		visit(set)
+	}
+	return name, children
+}
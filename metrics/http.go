@@ -0,0 +1,104 @@
+package metrics
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/parser"
+)
+
+// noCode labels the errors without extensions.code, the syntax and
+// validation errors of the graphql-go servers.
+const noCode = "NONE"
+
+type contextKey int
+
+const (
+	operationKey contextKey = iota
+	pathKey
+	requestKey
+)
+
+// request is what the Middleware learns of the request it records from the
+// schema executing it.
+type request struct {
+	operation string
+}
+
+// executing returns ctx carrying the name of the operation a schema
+// executes, for the resolvers to be labeled with, and hands it to the
+// Middleware recording the request if any. The name is operationName or,
+// if empty, the name of the only operation of query.
+func executing(ctx context.Context, query, operationName string) context.Context {
+	operation := operationName
+	if operation == "" && strings.TrimSpace(query) != "" {
+		if doc, err := parser.ParseQuery(&ast.Source{Input: query}); err == nil && len(doc.Operations) == 1 {
+			operation = doc.Operations[0].Name
+		}
+	}
+	if r, ok := ctx.Value(requestKey).(*request); ok {
+		r.operation = operation
+	}
+	return context.WithValue(ctx, operationKey, operation)
+}
+
+func operationOf(ctx context.Context) (string, bool) {
+	operation, ok := ctx.Value(operationKey).(string)
+	return operation, ok
+}
+
+// Middleware records every request answered by next, a graph-gophers or
+// graphql-go handler: its operation, how long it took and the codes of the
+// errors of the response. The operation is named by the Tracer or the
+// GraphQLGoExtension of the schema, from what the schema executes rather
+// than from the request, and is anonymous for requests that executed
+// nothing. The same Tracer or extension times the resolvers.
+func (m *Metrics) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		req := &request{}
+		rec := &recorder{ResponseWriter: w}
+		next.ServeHTTP(rec, r.WithContext(context.WithValue(r.Context(), requestKey, req)))
+		m.ObserveRequest(req.operation, time.Since(start), errorCodes(rec.body.Bytes()))
+	})
+}
+
+// recorder keeps a copy of the response written through it.
+type recorder struct {
+	http.ResponseWriter
+	body bytes.Buffer
+}
+
+func (r *recorder) Write(b []byte) (int, error) {
+	r.body.Write(b)
+	return r.ResponseWriter.Write(b)
+}
+
+// errorCodes returns the extensions.code of every error of a GraphQL
+// response.
+func errorCodes(body []byte) []string {
+	var resp struct {
+		Errors []struct {
+			Extensions struct {
+				Code string `json:"code"`
+			} `json:"extensions"`
+		} `json:"errors"`
+	}
+	if json.Unmarshal(body, &resp) != nil {
+		return nil
+	}
+	var codes []string
+	for _, err := range resp.Errors {
+		code := err.Extensions.Code
+		if code == "" {
+			code = noCode
+		}
+		codes = append(codes, code)
+	}
+	return codes
+}
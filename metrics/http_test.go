@@ -0,0 +1,102 @@
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	gophers "github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/handler"
+)
+
+type gophersQuery struct{}
+
+func (*gophersQuery) Hero(context.Context) string { return "R2-D2" }
+
+func TestMiddleware(t *testing.T) {
+	tests := []struct {
+		name        string
+		method      string
+		url         string
+		contentType string
+		body        string
+		// operations are the operations executed by each server, which do
+		// not all read the same parts of a request. A server without one
+		// is not tested.
+		operations map[string]string
+	}{
+		{"named in the URL of a POST", http.MethodPost, "/?query=" + url.QueryEscape(`query Hero { hero }`), "application/json", `{}`,
+			map[string]string{"graphql-go": "Hero", "graph-gophers": "anonymous"}},
+		{"chosen among several", http.MethodPost, "/", "application/json", `{"query": "query A { hero } query B { hero }", "operationName": "B"}`,
+			map[string]string{"graphql-go": "B", "graph-gophers": "B"}},
+		{"only one in a GraphQL body", http.MethodPost, "/", "application/graphql", `query Hero { hero }`,
+			map[string]string{"graphql-go": "Hero"}},
+		{"anonymous", http.MethodGet, "/?query=" + url.QueryEscape(`{ hero }`), "", "",
+			map[string]string{"graphql-go": "anonymous"}},
+	}
+
+	servers := map[string]func(*Metrics) http.Handler{
+		"graphql-go": func(m *Metrics) http.Handler {
+			schema, err := graphql.NewSchema(graphql.SchemaConfig{
+				Query: graphql.NewObject(graphql.ObjectConfig{
+					Name: "Query",
+					Fields: graphql.Fields{
+						"hero": &graphql.Field{
+							Type: graphql.String,
+							Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+								return "R2-D2", nil
+							},
+						},
+					},
+				}),
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			schema.AddExtensions(GraphQLGoExtension{Metrics: m})
+			return handler.New(&handler.Config{Schema: &schema})
+		},
+		"graph-gophers": func(m *Metrics) http.Handler {
+			s := gophers.MustParseSchema(`type Query { hero: String! }`, &gophersQuery{}, gophers.Tracer(Tracer{Metrics: m}))
+			return &relay.Handler{Schema: s}
+		},
+	}
+
+	for server, newHandler := range servers {
+		for _, test := range tests {
+			operation, ok := test.operations[server]
+			if !ok {
+				continue
+			}
+			t.Run(server+"/"+test.name, func(t *testing.T) {
+				m := New()
+				srv := httptest.NewServer(m.Middleware(newHandler(m)))
+				defer srv.Close()
+				req, err := http.NewRequest(test.method, srv.URL+test.url, strings.NewReader(test.body))
+				if err != nil {
+					t.Fatal(err)
+				}
+				if test.contentType != "" {
+					req.Header.Set("Content-Type", test.contentType)
+				}
+				resp, err := http.DefaultClient.Do(req)
+				if err != nil {
+					t.Fatal(err)
+				}
+				resp.Body.Close()
+
+				rec := httptest.NewRecorder()
+				m.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+				want := `graphql_requests_total{operation="` + operation + `"} 1`
+				if got := rec.Body.String(); !strings.Contains(got, want) {
+					t.Errorf("got metrics\n%s\nwant %s", got, want)
+				}
+			})
+		}
+	}
+}
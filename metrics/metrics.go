@@ -0,0 +1,207 @@
+// Package metrics records the requests and resolvers of the GraphQL servers
+// and serves them in the Prometheus text exposition format:
+//
+//	graphql_requests_total{operation}
+//	graphql_request_duration_seconds{operation}
+//	graphql_errors_total{operation,code}
+//	graphql_resolver_duration_seconds{operation,path}
+//
+// The path of a resolver is made of the names of the fields leading to it,
+// without aliases nor list indexes, like hero.friends.name, so that every
+// field of an operation has a single series. Only fields with a resolver of
+// their own are timed, not those read from a struct. The operation label
+// takes the first 100 operation names, and is "other" for any later one.
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// anonymous is the operation label of operations without a name.
+	anonymous = "anonymous"
+	// other is the operation label of the operations named after the first
+	// maxOperations names, so that clients sending ever new names cannot
+	// grow the metrics without bound.
+	other         = "other"
+	maxOperations = 100
+)
+
+var (
+	requestBuckets  = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+	resolverBuckets = []float64{.0001, .0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1}
+)
+
+// Metrics holds the metrics of a server. It is safe for concurrent use and
+// serves the metrics over HTTP.
+type Metrics struct {
+	mu sync.Mutex
+	// operations are the operation labels given so far, but other.
+	operations map[string]bool
+	requests   map[string]*histogram
+	errors     map[[2]string]int
+	resolvers  map[[2]string]*histogram
+}
+
+// New returns metrics with nothing recorded.
+func New() *Metrics {
+	return &Metrics{
+		operations: map[string]bool{},
+		requests:   map[string]*histogram{},
+		errors:     map[[2]string]int{},
+		resolvers:  map[[2]string]*histogram{},
+	}
+}
+
+// ObserveRequest records a request for operation that took d and answered
+// with errors of the given codes.
+func (m *Metrics) ObserveRequest(operation string, d time.Duration, codes []string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	operation = m.operationLabel(operation)
+	h := m.requests[operation]
+	if h == nil {
+		h = newHistogram(requestBuckets)
+		m.requests[operation] = h
+	}
+	h.observe(d.Seconds())
+	for _, code := range codes {
+		m.errors[[2]string{operation, code}]++
+	}
+}
+
+// ObserveResolver records a resolver of the field at path in operation that
+// took d.
+func (m *Metrics) ObserveResolver(operation, path string, d time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	key := [2]string{m.operationLabel(operation), path}
+	h := m.resolvers[key]
+	if h == nil {
+		h = newHistogram(resolverBuckets)
+		m.resolvers[key] = h
+	}
+	h.observe(d.Seconds())
+}
+
+// operationLabel returns the label of operation, other if it is new and
+// maxOperations labels are given already. The caller holds mu.
+func (m *Metrics) operationLabel(operation string) string {
+	if operation == "" {
+		operation = anonymous
+	}
+	if !m.operations[operation] {
+		if len(m.operations) >= maxOperations {
+			return other
+		}
+		m.operations[operation] = true
+	}
+	return operation
+}
+
+// ServeHTTP writes the metrics in the Prometheus text format, series sorted
+// by labels.
+func (m *Metrics) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var operations []string
+	for operation := range m.requests {
+		operations = append(operations, operation)
+	}
+	sort.Strings(operations)
+
+	header(w, "graphql_requests_total", "counter", "GraphQL requests by operation.")
+	for _, operation := range operations {
+		fmt.Fprintf(w, "graphql_requests_total%s %d\n", labels("operation", operation), m.requests[operation].count)
+	}
+
+	header(w, "graphql_request_duration_seconds", "histogram", "Time taken to answer GraphQL requests by operation.")
+	for _, operation := range operations {
+		m.requests[operation].write(w, "graphql_request_duration_seconds", "operation", operation)
+	}
+
+	errors := make([][2]string, 0, len(m.errors))
+	for key := range m.errors {
+		errors = append(errors, key)
+	}
+	sortKeys(errors)
+	header(w, "graphql_errors_total", "counter", "Errors in GraphQL responses by operation and extensions.code.")
+	for _, key := range errors {
+		fmt.Fprintf(w, "graphql_errors_total%s %d\n", labels("operation", key[0], "code", key[1]), m.errors[key])
+	}
+
+	resolvers := make([][2]string, 0, len(m.resolvers))
+	for key := range m.resolvers {
+		resolvers = append(resolvers, key)
+	}
+	sortKeys(resolvers)
+	header(w, "graphql_resolver_duration_seconds", "histogram", "Time taken by resolvers by operation and field path.")
+	for _, key := range resolvers {
+		m.resolvers[key].write(w, "graphql_resolver_duration_seconds", "operation", key[0], "path", key[1])
+	}
+}
+
+func header(w io.Writer, name, typ, help string) {
+	fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, typ)
+}
+
+func sortKeys(keys [][2]string) {
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i][0] != keys[j][0] {
+			return keys[i][0] < keys[j][0]
+		}
+		return keys[i][1] < keys[j][1]
+	})
+}
+
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// labels formats name and value pairs as a label set.
+func labels(pairs ...string) string {
+	l := make([]string, 0, len(pairs)/2)
+	for i := 0; i < len(pairs); i += 2 {
+		l = append(l, pairs[i]+`="`+labelEscaper.Replace(pairs[i+1])+`"`)
+	}
+	return "{" + strings.Join(l, ",") + "}"
+}
+
+// histogram counts observations in cumulative buckets.
+type histogram struct {
+	bounds []float64
+	counts []int
+	count  int
+	sum    float64
+}
+
+func newHistogram(bounds []float64) *histogram {
+	return &histogram{bounds: bounds, counts: make([]int, len(bounds))}
+}
+
+func (h *histogram) observe(v float64) {
+	for i, bound := range h.bounds {
+		if v <= bound {
+			h.counts[i]++
+		}
+	}
+	h.count++
+	h.sum += v
+}
+
+func (h *histogram) write(w io.Writer, name string, pairs ...string) {
+	for i, bound := range h.bounds {
+		le := strconv.FormatFloat(bound, 'g', -1, 64)
+		fmt.Fprintf(w, "%s_bucket%s %d\n", name, labels(append(pairs, "le", le)...), h.counts[i])
+	}
+	fmt.Fprintf(w, "%s_bucket%s %d\n", name, labels(append(pairs, "le", "+Inf")...), h.count)
+	fmt.Fprintf(w, "%s_sum%s %s\n", name, labels(pairs...), strconv.FormatFloat(h.sum, 'g', -1, 64))
+	fmt.Fprintf(w, "%s_count%s %d\n", name, labels(pairs...), h.count)
+}
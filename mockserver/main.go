@@ -0,0 +1,81 @@
+// Command mockserver serves a mock GraphQL endpoint for any SDL schema, so
+// that clients can be written before the resolvers exist:
+//
+//	go run ./mockserver gqlgen/graph/schema.graphqls
+//	go run ./mockserver -seed 2 -list-length 5 -overrides mocks.yaml gqlgen/graph/schema.graphqls
+//
+// Every field is resolved with a fake value that only depends on the seed
+// and on the path to the field, so responses are stable across requests.
+// Interfaces and unions resolve to one of their concrete types, null if they
+// have none, and the arguments of a field are echoed in the objects it
+// returns, so that a mutation returns the object it was given. Lists are
+// cut to the first or last arguments of their field, or of the field that
+// returned their object, such as a connection. An override file pins list
+// lengths and field values by coordinate:
+//
+//	lists:
+//	  Query.todos: 5
+//	values:
+//	  User.name: Ada Lovelace
+//	  Query.todos:
+//	    - text: Write the mocks
+//	      done: true
+package main
+
+import (
+	"flag"
+	"graphql/explorer"
+	"io/ioutil"
+	"log"
+	"net/http"
+
+	"github.com/graphql-go/handler"
+	"github.com/vektah/gqlparser/v2"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+var (
+	addr          = flag.String("addr", ":8080", "address to listen on")
+	seed          = flag.Int64("seed", 1, "seed of the fake values")
+	listLength    = flag.Int("list-length", 3, "length of mocked lists")
+	overridesPath = flag.String("overrides", "", "JSON or YAML file of list lengths and field values to pin")
+	explore       = explorer.Flags("Mock", "/query")
+)
+
+func main() {
+	flag.Parse()
+	if flag.NArg() == 0 {
+		log.Fatal("usage: mockserver [flags] schema.graphql ...")
+	}
+
+	var sources []*ast.Source
+	for _, file := range flag.Args() {
+		b, err := ioutil.ReadFile(file)
+		if err != nil {
+			log.Fatal(err)
+		}
+		sources = append(sources, &ast.Source{Name: file, Input: string(b)})
+	}
+	sdl, gqlErr := gqlparser.LoadSchema(sources...)
+	if gqlErr != nil {
+		log.Fatal(gqlErr)
+	}
+
+	m := &mocker{seed: *seed, listLength: *listLength}
+	if *overridesPath != "" {
+		o, err := loadOverrides(*overridesPath)
+		if err != nil {
+			log.Fatal(err)
+		}
+		m.overrides = o
+	}
+	schema, err := buildSchema(sdl, m)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	http.Handle("/", explorer.Handler(*explore))
+	http.Handle("/query", handler.New(&handler.Config{Schema: &schema, Pretty: true}))
+	log.Printf("serving mocks of %v on %s", flag.Args(), *addr)
+	log.Fatal(http.ListenAndServe(*addr, nil))
+}
@@ -0,0 +1,302 @@
+package main
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math"
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/graphql-go/graphql"
+)
+
+// mocker resolves every field with fake values. A value is derived from
+// the seed and the path of field names and list indexes leading to it, so
+// that the same field of the same object gets the same value in every
+// response, whatever else is selected.
+type mocker struct {
+	seed       int64
+	listLength int
+	overrides  overrides
+}
+
+// object is a mocked object.
+type object struct {
+	typeName string
+	// key seeds the values of the fields of the object.
+	key string
+	// pinned are values of fields of the object set by the overrides or
+	// echoed from the arguments of the field that returned it.
+	pinned map[string]interface{}
+	// paging are the first and last arguments of the field that returned
+	// the object, such as a connection, which size its lists.
+	paging map[string]interface{}
+}
+
+func (m *mocker) resolve(p graphql.ResolveParams) (interface{}, error) {
+	parentType := p.Info.ParentType.Name()
+	parent, ok := p.Source.(*object)
+	if !ok {
+		parent = &object{typeName: parentType, key: parentType}
+	}
+	coordinate := parentType + "." + p.Info.FieldName
+	key := parent.key + "." + p.Info.FieldName
+	if len(p.Args) > 0 {
+		// fmt prints maps sorted by key.
+		key += fmt.Sprint(p.Args)
+	}
+	g := generation{m, p.Info.Schema, p.Info.FieldName, coordinate}
+
+	v := g.value(p.Info.ReturnType, key, parent.pinned, p.Args)
+	paging := pagingArgs(p.Args)
+	if paging == nil {
+		return page(v, parent.paging), nil
+	}
+	if o, ok := v.(*object); ok {
+		o.paging = paging
+	}
+	return page(v, paging), nil
+}
+
+// value returns the value of type t of the field: the one pinned on its
+// parent or by the overrides, or else a mocked one.
+func (g generation) value(t graphql.Type, key string, pinned, args map[string]interface{}) interface{} {
+	if v, ok := pinned[g.field]; ok {
+		if v, ok := g.pinned(t, key, v); ok {
+			return v
+		}
+	}
+	if v, ok := g.overrides.Values[g.coordinate]; ok {
+		if v, ok := g.pinned(t, key, v); ok {
+			return v
+		}
+	}
+	return g.mock(t, key, echo(args))
+}
+
+// pagingArgs returns the first and last arguments in args, nil if neither
+// is given.
+func pagingArgs(args map[string]interface{}) map[string]interface{} {
+	var paging map[string]interface{}
+	for _, name := range []string{"first", "last"} {
+		if n, ok := args[name].(int); ok {
+			if paging == nil {
+				paging = map[string]interface{}{}
+			}
+			paging[name] = n
+		}
+	}
+	return paging
+}
+
+// page returns v, if a list, cut to the first or last items asked for by
+// paging.
+func page(v interface{}, paging map[string]interface{}) interface{} {
+	l, ok := v.([]interface{})
+	if !ok {
+		return v
+	}
+	if first, ok := paging["first"].(int); ok && first < len(l) {
+		l = l[:max(first, 0)]
+	}
+	if last, ok := paging["last"].(int); ok && last < len(l) {
+		l = l[len(l)-max(last, 0):]
+	}
+	return l
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+// echo returns the values the arguments of a field pin in the objects it
+// returns, so that a mutation returns what it was given: the arguments
+// themselves, the fields of input objects, and a reference such as userId
+// as the id of the user field.
+func echo(args map[string]interface{}) map[string]interface{} {
+	pins := map[string]interface{}{}
+	var add func(name string, v interface{})
+	add = func(name string, v interface{}) {
+		if fields, ok := v.(map[string]interface{}); ok {
+			for name, v := range fields {
+				add(name, v)
+			}
+			return
+		}
+		pins[name] = v
+		for _, suffix := range []string{"Id", "ID"} {
+			if ref := strings.TrimSuffix(name, suffix); ref != name && ref != "" {
+				pins[ref] = map[string]interface{}{"id": v}
+			}
+		}
+	}
+	for name, v := range args {
+		add(name, v)
+	}
+	if len(pins) == 0 {
+		return nil
+	}
+	return pins
+}
+
+// generation mocks the value of a field.
+type generation struct {
+	*mocker
+	schema     graphql.Schema
+	field      string
+	coordinate string
+}
+
+// mock returns a fake value of type t, seeded by key. The objects it
+// returns have the fields in pins set.
+func (g generation) mock(t graphql.Type, key string, pins map[string]interface{}) interface{} {
+	switch t := t.(type) {
+	case *graphql.NonNull:
+		return g.mock(t.OfType, key, pins)
+	case *graphql.List:
+		n := g.listLength
+		if length, ok := g.overrides.Lists[g.coordinate]; ok {
+			n = length
+		}
+		l := make([]interface{}, n)
+		for i := range l {
+			l[i] = g.mock(t.OfType, key+"."+strconv.Itoa(i), pins)
+		}
+		return l
+	case *graphql.Object:
+		return &object{typeName: t.Name(), key: key, pinned: pins}
+	case *graphql.Interface, *graphql.Union:
+		typeName, ok := g.pick(t.(graphql.Abstract), key)
+		if !ok {
+			return nil
+		}
+		return &object{typeName: typeName, key: key, pinned: pins}
+	case *graphql.Enum:
+		values := t.Values()
+		return values[g.rand(key).Intn(len(values))].Value
+	case *graphql.Scalar:
+		return g.scalar(t.Name(), key)
+	}
+	return nil
+}
+
+// pinned returns the value v given for a field of type t, with the fields
+// left out of its objects mocked. It returns false if v does not fit t.
+func (g generation) pinned(t graphql.Type, key string, v interface{}) (interface{}, bool) {
+	if v == nil {
+		return nil, true
+	}
+	switch t := t.(type) {
+	case *graphql.NonNull:
+		return g.pinned(t.OfType, key, v)
+	case *graphql.List:
+		items, ok := v.([]interface{})
+		if !ok {
+			return nil, false
+		}
+		l := make([]interface{}, len(items))
+		for i, item := range items {
+			if l[i], ok = g.pinned(t.OfType, key+"."+strconv.Itoa(i), item); !ok {
+				return nil, false
+			}
+		}
+		return l, true
+	case *graphql.Object:
+		fields, ok := v.(map[string]interface{})
+		return &object{typeName: t.Name(), key: key, pinned: fields}, ok
+	case *graphql.Interface, *graphql.Union:
+		fields, ok := v.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		typeName, ok := fields["__typename"].(string)
+		if !ok {
+			if typeName, ok = g.pick(t.(graphql.Abstract), key); !ok {
+				return nil, true
+			}
+		}
+		return &object{typeName: typeName, key: key, pinned: fields}, true
+	}
+	return v, true
+}
+
+// pick returns one of the possible types of t, seeded by key, or false if
+// it has none, such as an interface no object implements yet. Its value is
+// then null.
+func (g generation) pick(t graphql.Abstract, key string) (string, bool) {
+	var names []string
+	for _, o := range g.schema.PossibleTypes(t) {
+		names = append(names, o.Name())
+	}
+	if len(names) == 0 {
+		return "", false
+	}
+	sort.Strings(names)
+	return names[g.rand(key).Intn(len(names))], true
+}
+
+func (g generation) rand(key string) *rand.Rand {
+	h := fnv.New64a()
+	fmt.Fprint(h, g.seed, key)
+	return rand.New(rand.NewSource(int64(h.Sum64())))
+}
+
+var (
+	firstNames = []string{"Ada", "Alan", "Barbara", "Dennis", "Edsger", "Frances", "Grace", "Ken", "Margaret", "Radia"}
+	lastNames  = []string{"Allen", "Dijkstra", "Hamilton", "Hopper", "Kay", "Liskov", "Lovelace", "Perlman", "Ritchie", "Thompson"}
+	words      = []string{"alpha", "bright", "calm", "delta", "echo", "fern", "glow", "harbor", "iris", "jade", "kite", "lumen", "maple", "north", "orbit", "pine"}
+	// epoch is the earliest mocked time.
+	epoch = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+)
+
+// scalar returns a fake value of the scalar name, shaped after the name of
+// the field for strings.
+func (g generation) scalar(name, key string) interface{} {
+	r := g.rand(key)
+	switch name {
+	case "ID":
+		return strconv.Itoa(1 + r.Intn(9999))
+	case "Int":
+		return r.Intn(100)
+	case "Float":
+		return math.Round(r.Float64()*10000) / 100
+	case "Boolean":
+		return r.Intn(2) == 1
+	case "String":
+		return g.text(r)
+	}
+	lower := strings.ToLower(name)
+	if strings.Contains(lower, "time") || strings.Contains(lower, "date") {
+		t := epoch.Add(time.Duration(r.Int63n(int64(3 * 365 * 24 * time.Hour))))
+		if !strings.Contains(lower, "time") {
+			return t.Format("2006-01-02")
+		}
+		return t.Truncate(time.Second).Format(time.RFC3339)
+	}
+	return fmt.Sprintf("%s-%d", name, r.Intn(1000))
+}
+
+func (g generation) text(r *rand.Rand) string {
+	field := strings.ToLower(g.field)
+	first, last := firstNames[r.Intn(len(firstNames))], lastNames[r.Intn(len(lastNames))]
+	switch {
+	case strings.Contains(field, "email"):
+		return strings.ToLower(first+"."+last) + "@example.com"
+	case strings.Contains(field, "name"):
+		return first + " " + last
+	case strings.Contains(field, "url"):
+		return "https://example.com/" + words[r.Intn(len(words))]
+	}
+	l := make([]string, 2+r.Intn(5))
+	for i := range l {
+		l[i] = words[r.Intn(len(words))]
+	}
+	s := strings.Join(l, " ")
+	return strings.ToUpper(s[:1]) + s[1:]
+}
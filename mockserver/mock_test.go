@@ -0,0 +1,210 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/vektah/gqlparser/v2"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+const testSDL = `
+type Query {
+  todos(first: Int, last: Int): [Todo!]!
+  users(first: Int): UserConnection!
+  node(id: ID!): Node
+  orphan: Orphan
+}
+
+type Mutation {
+  createTodo(input: NewTodo!): Todo!
+}
+
+input NewTodo {
+  text: String!
+  userId: ID!
+}
+
+interface Node { id: ID! }
+interface Orphan { id: ID! }
+
+type Todo implements Node {
+  id: ID!
+  text: String!
+  done: Boolean!
+  user: User!
+}
+
+type User implements Node {
+  id: ID!
+  name: String!
+  email: String!
+}
+
+type UserConnection {
+  edges: [UserEdge!]!
+}
+
+type UserEdge {
+  node: User!
+}
+`
+
+// query executes query against a mock of testSDL.
+func query(t *testing.T, m *mocker, query string) map[string]interface{} {
+	t.Helper()
+	sdl, gqlErr := gqlparser.LoadSchema(&ast.Source{Input: testSDL})
+	if gqlErr != nil {
+		t.Fatal(gqlErr)
+	}
+	schema, err := buildSchema(sdl, m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	result := graphql.Do(graphql.Params{Schema: schema, RequestString: query})
+	if len(result.Errors) > 0 {
+		t.Fatalf("got errors %v", result.Errors)
+	}
+	// Read the result back from JSON to compare it with plain values.
+	b, err := json.Marshal(result.Data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var data map[string]interface{}
+	if err := json.Unmarshal(b, &data); err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func TestMockStable(t *testing.T) {
+	q := `{ todos { id text done user { name email } } }`
+	want := query(t, &mocker{seed: 1, listLength: 3}, q)
+	if got := query(t, &mocker{seed: 1, listLength: 3}, q); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want the same as before %v", got, want)
+	}
+	if got := query(t, &mocker{seed: 2, listLength: 3}, q); reflect.DeepEqual(got, want) {
+		t.Errorf("got %v for another seed, want other values", got)
+	}
+	// A field keeps its value whatever else is selected.
+	got := query(t, &mocker{seed: 1, listLength: 3}, `{ todos { text } }`)
+	text := want["todos"].([]interface{})[1].(map[string]interface{})["text"]
+	if got := got["todos"].([]interface{})[1].(map[string]interface{})["text"]; got != text {
+		t.Errorf("got text %v when selected alone, want %v", got, text)
+	}
+}
+
+func TestMockPaging(t *testing.T) {
+	m := &mocker{seed: 1, listLength: 5}
+	data := query(t, m, `{ all: todos { id } first: todos(first: 2) { id } last: todos(last: 1) { id } users(first: 3) { edges { node { id } } } }`)
+	all := data["all"].([]interface{})
+	if len(all) != 5 {
+		t.Fatalf("got %d todos, want 5", len(all))
+	}
+	// The arguments seed the values, so only the lengths are compared.
+	if got := data["first"].([]interface{}); len(got) != 2 {
+		t.Errorf("got %d todos for first 2, want 2", len(got))
+	}
+	if got := data["last"].([]interface{}); len(got) != 1 {
+		t.Errorf("got %d todos for last 1, want 1", len(got))
+	}
+	// The edges of a connection are cut by the arguments of its field.
+	if edges := data["users"].(map[string]interface{})["edges"].([]interface{}); len(edges) != 3 {
+		t.Errorf("got %d edges, want 3", len(edges))
+	}
+}
+
+func TestMockEcho(t *testing.T) {
+	data := query(t, &mocker{seed: 1, listLength: 3}, `mutation {
+		createTodo(input: {text: "Write tests", userId: "42"}) { text user { id name } }
+	}`)
+	todo := data["createTodo"].(map[string]interface{})
+	if todo["text"] != "Write tests" {
+		t.Errorf("got text %v, want the one given", todo["text"])
+	}
+	if user := todo["user"].(map[string]interface{}); user["id"] != "42" || user["name"] == "" {
+		t.Errorf("got user %v, want user 42 with a mocked name", user)
+	}
+}
+
+func TestMockAbstract(t *testing.T) {
+	data := query(t, &mocker{seed: 1, listLength: 3}, `{
+		node(id: "1") { __typename id }
+		orphan { id }
+	}`)
+	node := data["node"].(map[string]interface{})
+	if typeName := node["__typename"]; typeName != "Todo" && typeName != "User" {
+		t.Errorf("got node of type %v, want a Todo or a User", typeName)
+	}
+	if node["id"] != "1" {
+		t.Errorf("got node %v, want the id asked for", node["id"])
+	}
+	if data["orphan"] != nil {
+		t.Errorf("got %v for an interface without objects, want null", data["orphan"])
+	}
+}
+
+func TestOverrides(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"mocks.yaml": `
+lists:
+  Query.todos: 1
+values:
+  User.name: Ada Lovelace
+  Query.todos:
+    - text: Write the mocks
+      user: {email: ada@example.com}
+`,
+		"mocks.json": `{
+  "lists": {"Query.todos": 1},
+  "values": {
+    "User.name": "Ada Lovelace",
+    "Query.todos": [{"text": "Write the mocks", "user": {"email": "ada@example.com"}}]
+  }
+}`,
+	}
+	for name, content := range files {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(dir, name)
+			if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+				t.Fatal(err)
+			}
+			o, err := loadOverrides(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			m := &mocker{seed: 1, listLength: 3, overrides: o}
+			data := query(t, m, `{ todos { text done user { name email } } users { edges { node { name } } } }`)
+			todos := data["todos"].([]interface{})
+			if len(todos) != 1 {
+				t.Fatalf("got %d todos, want the 1 pinned", len(todos))
+			}
+			todo := todos[0].(map[string]interface{})
+			if _, ok := todo["done"].(bool); todo["text"] != "Write the mocks" || !ok {
+				t.Errorf("got todo %v, want the text pinned and done mocked", todo)
+			}
+			want := map[string]interface{}{"name": "Ada Lovelace", "email": "ada@example.com"}
+			if user := todo["user"]; !reflect.DeepEqual(user, want) {
+				t.Errorf("got user %v, want %v", user, want)
+			}
+			// The length of a list is pinned only for its coordinate.
+			edges := data["users"].(map[string]interface{})["edges"].([]interface{})
+			if len(edges) != 3 {
+				t.Errorf("got %d edges, want 3", len(edges))
+			}
+		})
+	}
+
+	path := filepath.Join(dir, "mocks.toml")
+	if err := os.WriteFile(path, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := loadOverrides(path); err == nil {
+		t.Error("loaded overrides of an unknown format")
+	}
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+
+	"gopkg.in/yaml.v2"
+)
+
+// overrides pin parts of the mocked responses, by field coordinate such as
+// Query.todos.
+type overrides struct {
+	// Lists are the lengths of mocked lists.
+	Lists map[string]int `json:"lists" yaml:"lists"`
+	// Values are the values of fields. The fields left out of the objects
+	// of a value are mocked.
+	Values map[string]interface{} `json:"values" yaml:"values"`
+}
+
+// loadOverrides reads an override file, in JSON or in YAML as told by its
+// extension.
+func loadOverrides(path string) (overrides, error) {
+	var o overrides
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return o, err
+	}
+	switch filepath.Ext(path) {
+	case ".json":
+		err = json.Unmarshal(b, &o)
+	case ".yaml", ".yml":
+		err = yaml.UnmarshalStrict(b, &o)
+		for name, v := range o.Values {
+			o.Values[name] = fromYAML(v)
+		}
+	default:
+		err = fmt.Errorf("unknown format %q", filepath.Ext(path))
+	}
+	if err != nil {
+		return o, fmt.Errorf("%s: %v", path, err)
+	}
+	return o, nil
+}
+
+// fromYAML converts the maps decoded by yaml.v2, keyed by interface{}, to
+// the maps keyed by string decoded from JSON.
+func fromYAML(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[interface{}]interface{}:
+		m := map[string]interface{}{}
+		for k, x := range v {
+			m[fmt.Sprint(k)] = fromYAML(x)
+		}
+		return m
+	case []interface{}:
+		for i, x := range v {
+			v[i] = fromYAML(x)
+		}
+	}
+	return v
+}
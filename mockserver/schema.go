@@ -0,0 +1,228 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+	gqlast "github.com/vektah/gqlparser/v2/ast"
+)
+
+var builtinScalars = map[string]*graphql.Scalar{
+	"String":  graphql.String,
+	"Int":     graphql.Int,
+	"Float":   graphql.Float,
+	"Boolean": graphql.Boolean,
+	"ID":      graphql.ID,
+}
+
+// builder turns a schema parsed from SDL into an executable graphql-go
+// schema whose fields are all resolved by a mocker.
+type builder struct {
+	sdl   *gqlast.Schema
+	mock  *mocker
+	types map[string]graphql.Type
+}
+
+func buildSchema(sdl *gqlast.Schema, mock *mocker) (graphql.Schema, error) {
+	b := &builder{sdl: sdl, mock: mock, types: map[string]graphql.Type{}}
+
+	// Named types are created first and refer to each other through
+	// thunks, evaluated once they all exist. Unions list their members, so
+	// they come after the objects.
+	for name, def := range sdl.Types {
+		if def.BuiltIn && builtinScalars[name] == nil {
+			continue
+		}
+		switch def.Kind {
+		case gqlast.Scalar:
+			b.types[name] = b.scalar(def)
+		case gqlast.Enum:
+			b.types[name] = b.enum(def)
+		case gqlast.Object:
+			b.types[name] = b.object(def)
+		case gqlast.Interface:
+			b.types[name] = b.iface(def)
+		case gqlast.InputObject:
+			b.types[name] = b.input(def)
+		}
+	}
+	for name, def := range sdl.Types {
+		if def.Kind == gqlast.Union {
+			b.types[name] = b.union(def)
+		}
+	}
+
+	config := graphql.SchemaConfig{}
+	if sdl.Query != nil {
+		config.Query = b.types[sdl.Query.Name].(*graphql.Object)
+	}
+	if sdl.Mutation != nil {
+		config.Mutation = b.types[sdl.Mutation.Name].(*graphql.Object)
+	}
+	if sdl.Subscription != nil {
+		config.Subscription = b.types[sdl.Subscription.Name].(*graphql.Object)
+	}
+	// Objects only reachable through an interface must be listed to be
+	// resolvable.
+	for _, t := range b.types {
+		config.Types = append(config.Types, t)
+	}
+	return graphql.NewSchema(config)
+}
+
+// typeOf returns the graphql-go type of a type reference.
+func (b *builder) typeOf(t *gqlast.Type) graphql.Type {
+	var typ graphql.Type
+	if t.Elem != nil {
+		typ = graphql.NewList(b.typeOf(t.Elem))
+	} else {
+		typ = b.types[t.NamedType]
+	}
+	if t.NonNull {
+		typ = graphql.NewNonNull(typ)
+	}
+	return typ
+}
+
+func (b *builder) scalar(def *gqlast.Definition) *graphql.Scalar {
+	if s := builtinScalars[def.Name]; s != nil {
+		return s
+	}
+	// Custom scalars are passed through as they are sent and as they are
+	// mocked.
+	identity := func(v interface{}) interface{} { return v }
+	return graphql.NewScalar(graphql.ScalarConfig{
+		Name:         def.Name,
+		Description:  def.Description,
+		Serialize:    identity,
+		ParseValue:   identity,
+		ParseLiteral: func(v ast.Value) interface{} { return v.GetValue() },
+	})
+}
+
+func (b *builder) enum(def *gqlast.Definition) *graphql.Enum {
+	values := graphql.EnumValueConfigMap{}
+	for _, v := range def.EnumValues {
+		values[v.Name] = &graphql.EnumValueConfig{
+			Value:             v.Name,
+			Description:       v.Description,
+			DeprecationReason: deprecationReason(v.Directives),
+		}
+	}
+	return graphql.NewEnum(graphql.EnumConfig{Name: def.Name, Description: def.Description, Values: values})
+}
+
+func (b *builder) object(def *gqlast.Definition) *graphql.Object {
+	return graphql.NewObject(graphql.ObjectConfig{
+		Name:        def.Name,
+		Description: def.Description,
+		Fields:      graphql.FieldsThunk(func() graphql.Fields { return b.fields(def) }),
+		Interfaces: graphql.InterfacesThunk(func() []*graphql.Interface {
+			var l []*graphql.Interface
+			for _, name := range def.Interfaces {
+				l = append(l, b.types[name].(*graphql.Interface))
+			}
+			return l
+		}),
+	})
+}
+
+func (b *builder) iface(def *gqlast.Definition) *graphql.Interface {
+	return graphql.NewInterface(graphql.InterfaceConfig{
+		Name:        def.Name,
+		Description: def.Description,
+		Fields:      graphql.FieldsThunk(func() graphql.Fields { return b.fields(def) }),
+		ResolveType: b.resolveType,
+	})
+}
+
+func (b *builder) union(def *gqlast.Definition) *graphql.Union {
+	var members []*graphql.Object
+	for _, name := range def.Types {
+		members = append(members, b.types[name].(*graphql.Object))
+	}
+	return graphql.NewUnion(graphql.UnionConfig{
+		Name:        def.Name,
+		Description: def.Description,
+		Types:       members,
+		ResolveType: b.resolveType,
+	})
+}
+
+// resolveType returns the concrete type the mocker chose for an object of
+// an interface or union.
+func (b *builder) resolveType(p graphql.ResolveTypeParams) *graphql.Object {
+	if o, ok := p.Value.(*object); ok {
+		if t, ok := b.types[o.typeName].(*graphql.Object); ok {
+			return t
+		}
+	}
+	return nil
+}
+
+func (b *builder) input(def *gqlast.Definition) *graphql.InputObject {
+	return graphql.NewInputObject(graphql.InputObjectConfig{
+		Name:        def.Name,
+		Description: def.Description,
+		Fields: graphql.InputObjectConfigFieldMapThunk(func() graphql.InputObjectConfigFieldMap {
+			fields := graphql.InputObjectConfigFieldMap{}
+			for _, f := range def.Fields {
+				fields[f.Name] = &graphql.InputObjectFieldConfig{
+					Type:         b.typeOf(f.Type),
+					DefaultValue: defaultValue(f.DefaultValue),
+					Description:  f.Description,
+				}
+			}
+			return fields
+		}),
+	})
+}
+
+func (b *builder) fields(def *gqlast.Definition) graphql.Fields {
+	fields := graphql.Fields{}
+	for _, f := range def.Fields {
+		if f.Name == "__schema" || f.Name == "__type" {
+			continue
+		}
+		args := graphql.FieldConfigArgument{}
+		for _, a := range f.Arguments {
+			args[a.Name] = &graphql.ArgumentConfig{
+				Type:         b.typeOf(a.Type),
+				DefaultValue: defaultValue(a.DefaultValue),
+				Description:  a.Description,
+			}
+		}
+		fields[f.Name] = &graphql.Field{
+			Name:              f.Name,
+			Description:       f.Description,
+			Type:              b.typeOf(f.Type),
+			Args:              args,
+			DeprecationReason: deprecationReason(f.Directives),
+			Resolve:           b.mock.resolve,
+		}
+	}
+	return fields
+}
+
+func defaultValue(v *gqlast.Value) interface{} {
+	if v == nil {
+		return nil
+	}
+	value, err := v.Value(nil)
+	if err != nil {
+		panic(fmt.Sprintf("default value %s: %v", v, err))
+	}
+	return value
+}
+
+func deprecationReason(directives gqlast.DirectiveList) string {
+	d := directives.ForName("deprecated")
+	if d == nil {
+		return ""
+	}
+	if reason := d.Arguments.ForName("reason"); reason != nil {
+		return reason.Value.Raw
+	}
+	return graphql.DefaultDeprecationReason
+}
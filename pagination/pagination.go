@@ -0,0 +1,98 @@
+// Package pagination implements the slicing rules of the Relay Cursor
+// Connections specification over lists held in memory.
+package pagination
+
+import (
+	"encoding/base64"
+	"fmt"
+	"graphql/apperr"
+	"strconv"
+	"strings"
+)
+
+// Args are the pagination arguments of a connection field.
+type Args struct {
+	First  *int
+	After  *string
+	Last   *int
+	Before *string
+}
+
+// Window is the part of a list selected by Args, from index From up to but
+// not including index To.
+type Window struct {
+	From            int
+	To              int
+	HasPreviousPage bool
+	HasNextPage     bool
+}
+
+// Slice applies args to a list of total elements.
+func Slice(total int, args Args) (Window, error) {
+	if args.First != nil && args.Last != nil {
+		return Window{}, apperr.BadUserInputf("first and last must not be used together")
+	}
+	if args.First != nil && *args.First < 0 {
+		return Window{}, apperr.BadUserInputf("first must not be negative, got %d", *args.First)
+	}
+	if args.Last != nil && *args.Last < 0 {
+		return Window{}, apperr.BadUserInputf("last must not be negative, got %d", *args.Last)
+	}
+
+	from, to := 0, total
+	if args.After != nil {
+		i, err := DecodeCursor(*args.After)
+		if err != nil {
+			return Window{}, err
+		}
+		from = min(i+1, total)
+	}
+	if args.Before != nil {
+		i, err := DecodeCursor(*args.Before)
+		if err != nil {
+			return Window{}, err
+		}
+		to = min(i, total)
+	}
+	if to < from {
+		to = from
+	}
+
+	if args.First != nil && to-from > *args.First {
+		to = from + *args.First
+	}
+	if args.Last != nil && to-from > *args.Last {
+		from = to - *args.Last
+	}
+	return Window{
+		From:            from,
+		To:              to,
+		HasPreviousPage: from > 0,
+		HasNextPage:     to < total,
+	}, nil
+}
+
+// EncodeCursor returns the cursor of the element at index i.
+func EncodeCursor(i int) string {
+	return base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf("cursor%d", i+1)))
+}
+
+// DecodeCursor returns the index of the element a cursor points to.
+func DecodeCursor(s string) (int, error) {
+	b, err := base64.StdEncoding.DecodeString(s)
+	if err != nil || !strings.HasPrefix(string(b), "cursor") {
+		return 0, apperr.BadUserInputf("invalid cursor %q", s)
+	}
+	i, err := strconv.Atoi(strings.TrimPrefix(string(b), "cursor"))
+	if err != nil || i < 1 {
+		return 0, apperr.BadUserInputf("invalid cursor %q", s)
+	}
+	return i - 1, nil
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
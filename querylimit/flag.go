@@ -0,0 +1,53 @@
+package querylimit
+
+import (
+	"flag"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Flags registers the command line flags that configure the limits,
+// starting from DefaultConfig, and returns the config they fill in.
+func Flags() *Config {
+	c := DefaultConfig
+	flag.IntVar(&c.MaxDepth, "max-depth", c.MaxDepth, "maximum depth of an operation, 0 for no limit")
+	flag.IntVar(&c.MaxCost, "max-cost", c.MaxCost, "maximum complexity of an operation, 0 for no limit")
+	flag.IntVar(&c.ListSize, "list-size", c.ListSize, "expected length of lists that are not paginated")
+	flag.Var(fieldCosts{&c}, "field-cost", "cost of a single field as Type.field=cost, may be repeated")
+	return &c
+}
+
+type fieldCosts struct {
+	config *Config
+}
+
+func (f fieldCosts) String() string {
+	if f.config == nil {
+		return ""
+	}
+	var l []string
+	for field, cost := range f.config.FieldCosts {
+		l = append(l, fmt.Sprintf("%s=%d", field, cost))
+	}
+	return strings.Join(l, ",")
+}
+
+func (f fieldCosts) Set(s string) error {
+	parts := strings.SplitN(s, "=", 2)
+	if len(parts) != 2 || !strings.Contains(parts[0], ".") {
+		return fmt.Errorf("field cost %q is not in the form Type.field=cost", s)
+	}
+	cost, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return err
+	}
+	if cost < 0 {
+		return fmt.Errorf("field cost %q is negative", s)
+	}
+	if f.config.FieldCosts == nil {
+		f.config.FieldCosts = map[string]int{}
+	}
+	f.config.FieldCosts[parts[0]] = cost
+	return nil
+}
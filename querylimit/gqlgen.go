@@ -0,0 +1,41 @@
+package querylimit
+
+import (
+	"context"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/99designs/gqlgen/graphql/errcode"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+const errLimitExceeded = "QUERY_LIMIT_EXCEEDED"
+
+// Extension enforces the limits in a gqlgen handler.
+type Extension struct {
+	Config Config
+
+	schema Schema
+}
+
+var _ interface {
+	graphql.OperationContextMutator
+	graphql.HandlerExtension
+} = &Extension{}
+
+func (e Extension) ExtensionName() string {
+	return "QueryLimit"
+}
+
+func (e *Extension) Validate(schema graphql.ExecutableSchema) error {
+	e.schema = ASTSchema(schema.Schema())
+	return nil
+}
+
+func (e Extension) MutateOperationContext(ctx context.Context, rc *graphql.OperationContext) *gqlerror.Error {
+	if _, err := e.Config.CheckOperation(e.schema, rc.Doc.Fragments, rc.Operation, rc.Variables); err != nil {
+		gqlErr := gqlerror.Errorf("%s", err.Error())
+		errcode.Set(gqlErr, errLimitExceeded)
+		return gqlErr
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+package querylimit
+
+import (
+	"context"
+	"graphql/apperr"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+)
+
+// Checker returns a function that checks an operation against the limits,
+// for the servers that read requests themselves to call on what they are
+// about to execute. Its error is an apperr error with the
+// QUERY_LIMIT_EXCEEDED code. Syntax errors are left for the server to
+// report.
+func (c Config) Checker(schema Schema) func(query, operationName string, variables map[string]interface{}) error {
+	return func(query, operationName string, variables map[string]interface{}) error {
+		if _, err := c.Check(schema, query, operationName, variables); err != nil {
+			return &apperr.Error{Code: errLimitExceeded, Message: err.Error()}
+		}
+		return nil
+	}
+}
+
+// GraphQLGo enforces the limits in schema, a graphql-go schema, on the
+// query, operation name and variables graphql-go executes, however the
+// request encoded them.
+//
+// graphql-go lets an extension fail a request only by panicking, which
+// loses the code of the error. So an extension checks the operation, and
+// the fields of the root types fail with its error before resolving
+// anything. Schemas sharing their root types may all be passed.
+func (c Config) GraphQLGo(schema *graphql.Schema) {
+	schema.AddExtensions(graphQLGoExtension{check: c.Checker(GraphQLGoSchema(schema))})
+	for _, root := range []*graphql.Object{schema.QueryType(), schema.MutationType(), schema.SubscriptionType()} {
+		if root == nil {
+			continue
+		}
+		for _, field := range root.Fields() {
+			field.Resolve = guard(field.Resolve)
+		}
+	}
+}
+
+type limitKey struct{}
+
+// guard returns resolve failing with the error an operation over the
+// limits left in its context.
+func guard(resolve graphql.FieldResolveFn) graphql.FieldResolveFn {
+	if resolve == nil {
+		resolve = graphql.DefaultResolveFn
+	}
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		if err, ok := p.Context.Value(limitKey{}).(error); ok {
+			return nil, err
+		}
+		return resolve(p)
+	}
+}
+
+type graphQLGoExtension struct {
+	check func(query, operationName string, variables map[string]interface{}) error
+}
+
+var _ graphql.Extension = graphQLGoExtension{}
+
+func (e graphQLGoExtension) Init(ctx context.Context, p *graphql.Params) context.Context {
+	if err := e.check(p.RequestString, p.OperationName, p.VariableValues); err != nil {
+		return context.WithValue(ctx, limitKey{}, err)
+	}
+	return ctx
+}
+
+func (e graphQLGoExtension) Name() string {
+	return "QueryLimit"
+}
+
+func (e graphQLGoExtension) ParseDidStart(ctx context.Context) (context.Context, graphql.ParseFinishFunc) {
+	return ctx, func(error) {}
+}
+
+func (e graphQLGoExtension) ValidationDidStart(ctx context.Context) (context.Context, graphql.ValidationFinishFunc) {
+	return ctx, func([]gqlerrors.FormattedError) {}
+}
+
+func (e graphQLGoExtension) ExecutionDidStart(ctx context.Context) (context.Context, graphql.ExecutionFinishFunc) {
+	return ctx, func(*graphql.Result) {}
+}
+
+func (e graphQLGoExtension) ResolveFieldDidStart(ctx context.Context, info *graphql.ResolveInfo) (context.Context, graphql.ResolveFieldFinishFunc) {
+	return ctx, func(interface{}, error) {}
+}
+
+func (e graphQLGoExtension) HasResult() bool {
+	return false
+}
+
+func (e graphQLGoExtension) GetResult(context.Context) interface{} {
+	return nil
+}
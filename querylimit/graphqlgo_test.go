@@ -0,0 +1,110 @@
+package querylimit
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/handler"
+)
+
+func TestGraphQLGo(t *testing.T) {
+	resolved := 0
+	var character *graphql.Object
+	character = graphql.NewObject(graphql.ObjectConfig{
+		Name: "Character",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"name":   &graphql.Field{Type: graphql.String},
+				"friend": &graphql.Field{Type: character},
+			}
+		}),
+	})
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"hero": &graphql.Field{
+					Type: character,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						resolved++
+						return map[string]interface{}{"name": "R2-D2"}, nil
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	Config{MaxDepth: 3}.GraphQLGo(&schema)
+	srv := httptest.NewServer(handler.New(&handler.Config{Schema: &schema}))
+	defer srv.Close()
+
+	const deep = `{ hero { friend { friend { name } } } }`
+	tests := []struct {
+		name        string
+		method      string
+		url         string
+		contentType string
+		body        string
+	}{
+		{"query in the URL of a POST", http.MethodPost, "/?query=" + url.QueryEscape(deep), "application/json", `{}`},
+		{"query in a JSON body", http.MethodPost, "/", "application/json", `{"query":` + quote(deep) + `}`},
+		{"query in a GraphQL body", http.MethodPost, "/", "application/graphql", deep},
+		{"query in a form body", http.MethodPost, "/", "application/x-www-form-urlencoded", "query=" + url.QueryEscape(deep)},
+		{"query in the URL of a GET", http.MethodGet, "/?query=" + url.QueryEscape(deep), "", ""},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req, err := http.NewRequest(test.method, srv.URL+test.url, strings.NewReader(test.body))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if test.contentType != "" {
+				req.Header.Set("Content-Type", test.contentType)
+			}
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer resp.Body.Close()
+			var result struct {
+				Data   map[string]interface{}
+				Errors []struct {
+					Extensions map[string]interface{}
+				}
+			}
+			if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+				t.Fatal(err)
+			}
+			if len(result.Errors) != 1 || result.Errors[0].Extensions["code"] != errLimitExceeded {
+				t.Errorf("got errors %+v, want one %s", result.Errors, errLimitExceeded)
+			}
+			if result.Data["hero"] != nil {
+				t.Errorf("got data %v, want no hero", result.Data)
+			}
+		})
+	}
+	if resolved != 0 {
+		t.Errorf("hero was resolved %d times, want never", resolved)
+	}
+
+	resp, err := http.Get(srv.URL + "/?query=" + url.QueryEscape(`{ hero { friend { name } } }`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resolved != 1 {
+		t.Errorf("hero was resolved %d times within the limits, want once", resolved)
+	}
+}
+
+func quote(s string) string {
+	b, _ := json.Marshal(s)
+	return string(b)
+}
@@ -0,0 +1,233 @@
+// Package querylimit rejects GraphQL operations that nest too deeply or would
+// cost too much to execute, before any resolver runs.
+//
+// The cost of a field is its own cost plus the cost of its selections. The
+// selections of a list field are counted once per expected element: the value
+// of its first or last argument when given, otherwise the first or last
+// argument of the enclosing connection field, otherwise Config.ListSize. A
+// negative first or last counts as 0, and costs too high to count stop at the
+// largest int, so that no argument can lower the cost of an operation.
+package querylimit
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/parser"
+)
+
+// Config describes the limits enforced on every operation.
+type Config struct {
+	// MaxDepth is the deepest level of nested fields allowed, 0 for no limit.
+	MaxDepth int
+	// MaxCost is the highest cost allowed, 0 for no limit.
+	MaxCost int
+	// FieldCosts overrides the cost of single fields, keyed by "Type.field".
+	FieldCosts map[string]int
+	// DefaultCost is the cost of a field missing from FieldCosts.
+	DefaultCost int
+	// ListSize is the expected length of a list that is not paginated.
+	ListSize int
+}
+
+// DefaultConfig is a sensible starting point for the Star Wars schemas.
+var DefaultConfig = Config{
+	MaxDepth:    10,
+	MaxCost:     1000,
+	DefaultCost: 1,
+	ListSize:    5,
+}
+
+// Error reports an operation that goes over one of the limits.
+type Error struct {
+	// Kind is either "depth" or "complexity".
+	Kind  string
+	Value int
+	Limit int
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("operation has %s %d, which exceeds the limit of %d", e.Kind, e.Value, e.Limit)
+}
+
+// Result is the outcome of analyzing an operation.
+type Result struct {
+	Depth int
+	Cost  int
+}
+
+// Check parses query, picks the operation to run and verifies it against
+// the limits. Syntax errors are left for the server to report.
+func (c Config) Check(schema Schema, query, operationName string, variables map[string]interface{}) (Result, error) {
+	doc, err := parser.ParseQuery(&ast.Source{Input: query})
+	if err != nil {
+		return Result{}, nil
+	}
+	op := operation(doc, operationName)
+	if op == nil {
+		return Result{}, nil
+	}
+	return c.CheckOperation(schema, doc.Fragments, op, variables)
+}
+
+// CheckOperation verifies an already parsed operation against the limits.
+func (c Config) CheckOperation(schema Schema, fragments ast.FragmentDefinitionList, op *ast.OperationDefinition, variables map[string]interface{}) (Result, error) {
+	if c.ListSize < 0 {
+		c.ListSize = 0
+	}
+	a := &analyzer{
+		config:    c,
+		schema:    schema,
+		fragments: fragments,
+		variables: variables,
+		visiting:  map[string]bool{},
+	}
+	res := a.selectionSet(schema.RootType(op.Operation), op.SelectionSet, c.ListSize)
+	if c.MaxDepth > 0 && res.Depth > c.MaxDepth {
+		return res, &Error{Kind: "depth", Value: res.Depth, Limit: c.MaxDepth}
+	}
+	if c.MaxCost > 0 && res.Cost > c.MaxCost {
+		return res, &Error{Kind: "complexity", Value: res.Cost, Limit: c.MaxCost}
+	}
+	return res, nil
+}
+
+func operation(doc *ast.QueryDocument, name string) *ast.OperationDefinition {
+	if name == "" {
+		if len(doc.Operations) != 1 {
+			return nil
+		}
+		return doc.Operations[0]
+	}
+	return doc.Operations.ForName(name)
+}
+
+type analyzer struct {
+	config    Config
+	schema    Schema
+	fragments ast.FragmentDefinitionList
+	variables map[string]interface{}
+	visiting  map[string]bool
+}
+
+// selectionSet returns the deepest nesting and the summed cost of the
+// selections made on typeName. listSize is the expected length of the list
+// fields selected here.
+func (a *analyzer) selectionSet(typeName string, set ast.SelectionSet, listSize int) Result {
+	var res Result
+	for _, sel := range set {
+		var r Result
+		switch sel := sel.(type) {
+		case *ast.Field:
+			r = a.field(typeName, sel, listSize)
+		case *ast.InlineFragment:
+			r = a.selectionSet(fragmentType(typeName, sel.TypeCondition), sel.SelectionSet, listSize)
+		case *ast.FragmentSpread:
+			def := a.fragments.ForName(sel.Name)
+			if def == nil || a.visiting[sel.Name] {
+				continue
+			}
+			a.visiting[sel.Name] = true
+			r = a.selectionSet(fragmentType(typeName, def.TypeCondition), def.SelectionSet, listSize)
+			delete(a.visiting, sel.Name)
+		}
+		if r.Depth > res.Depth {
+			res.Depth = r.Depth
+		}
+		res.Cost = add(res.Cost, r.Cost)
+	}
+	return res
+}
+
+func (a *analyzer) field(typeName string, field *ast.Field, listSize int) Result {
+	// Introspection is cheap and deeply nested by nature, so it is not counted.
+	if strings.HasPrefix(field.Name, "__") {
+		return Result{}
+	}
+
+	fieldType, list, _ := a.schema.FieldType(typeName, field.Name)
+
+	childListSize := a.config.ListSize
+	if n, ok := a.pageSize(field); ok {
+		childListSize = n
+		listSize = n
+	}
+
+	children := a.selectionSet(fieldType, field.SelectionSet, childListSize)
+	multiplier := 1
+	if list {
+		multiplier = listSize
+	}
+
+	cost, ok := a.config.FieldCosts[typeName+"."+field.Name]
+	if !ok {
+		cost = a.config.DefaultCost
+	}
+	if cost < 0 {
+		cost = 0
+	}
+	return Result{
+		Depth: children.Depth + 1,
+		Cost:  add(cost, mul(multiplier, children.Cost)),
+	}
+}
+
+// pageSize returns the first or last argument of a paginated field, 0 if
+// negative.
+func (a *analyzer) pageSize(field *ast.Field) (int, bool) {
+	for _, name := range []string{"first", "last"} {
+		arg := field.Arguments.ForName(name)
+		if arg == nil {
+			continue
+		}
+		v, err := arg.Value.Value(a.variables)
+		if err != nil {
+			continue
+		}
+		var n float64
+		switch v := v.(type) {
+		case int64:
+			n = float64(v)
+		case int:
+			n = float64(v)
+		case float64:
+			n = v
+		default:
+			continue
+		}
+		switch {
+		case n < 0:
+			return 0, true
+		case n >= float64(maxInt):
+			return maxInt, true
+		}
+		return int(n), true
+	}
+	return 0, false
+}
+
+const maxInt = int(^uint(0) >> 1)
+
+// add returns a+b, or maxInt if it does not fit. Both are at least 0.
+func add(a, b int) int {
+	if a > maxInt-b {
+		return maxInt
+	}
+	return a + b
+}
+
+// mul returns a*b, or maxInt if it does not fit. Both are at least 0.
+func mul(a, b int) int {
+	if a != 0 && b > maxInt/a {
+		return maxInt
+	}
+	return a * b
+}
+
+func fragmentType(typeName, condition string) string {
+	if condition == "" {
+		return typeName
+	}
+	return condition
+}
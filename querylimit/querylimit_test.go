@@ -0,0 +1,64 @@
+package querylimit
+
+import (
+	"testing"
+
+	"github.com/vektah/gqlparser/v2"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+const testSDL = `
+type Query { hero: Character }
+type Character {
+  name: String
+  friendsConnection(first: Int, last: Int): FriendsConnection
+}
+type FriendsConnection { friends: [Character] }
+`
+
+func TestCheckCost(t *testing.T) {
+	schema := ASTSchema(gqlparser.MustLoadSchema(&ast.Source{Input: testSDL}))
+	config := Config{MaxCost: 1000, DefaultCost: 1, ListSize: 5}
+
+	tests := []struct {
+		name  string
+		query string
+		cost  int
+		err   bool
+	}{
+		{
+			name:  "paginated",
+			query: `{ hero { friendsConnection(first: 10) { friends { name } } } }`,
+			// hero + friendsConnection + friends + 10 names
+			cost: 13,
+		},
+		{
+			name: "negative page does not offset a sibling",
+			query: `{ hero {
+				a: friendsConnection(first: -100000) { friends { name } }
+				b: friendsConnection(first: 1000) { friends { name } }
+			} }`,
+			cost: 1 + 2 + 1002,
+			err:  true,
+		},
+		{
+			name: "nested pages saturate",
+			query: `{ hero { friendsConnection(first: 4000000000) { friends {
+				friendsConnection(first: 4000000000) { friends {
+				friendsConnection(first: 4000000000) { friends { name } } } } } } } }`,
+			cost: maxInt,
+			err:  true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := config.Check(schema, test.query, "", nil)
+			if res.Cost != test.cost {
+				t.Errorf("got cost %d, want %d", res.Cost, test.cost)
+			}
+			if (err != nil) != test.err {
+				t.Errorf("got error %v, want one: %v", err, test.err)
+			}
+		})
+	}
+}
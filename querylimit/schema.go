@@ -0,0 +1,105 @@
+package querylimit
+
+import (
+	"github.com/graphql-go/graphql"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// Schema tells the analyzer the types that fields resolve to.
+type Schema interface {
+	// RootType returns the name of the root type of the operation.
+	RootType(op ast.Operation) string
+	// FieldType returns the named type of the field and whether it is a list.
+	FieldType(typeName, fieldName string) (fieldType string, list bool, ok bool)
+}
+
+type astSchema struct {
+	schema *ast.Schema
+}
+
+// ASTSchema adapts a schema loaded from SDL, as used by gqlgen and gqlparser.
+func ASTSchema(schema *ast.Schema) Schema {
+	return astSchema{schema}
+}
+
+func (s astSchema) RootType(op ast.Operation) string {
+	var def *ast.Definition
+	switch op {
+	case ast.Query:
+		def = s.schema.Query
+	case ast.Mutation:
+		def = s.schema.Mutation
+	case ast.Subscription:
+		def = s.schema.Subscription
+	}
+	if def == nil {
+		return ""
+	}
+	return def.Name
+}
+
+func (s astSchema) FieldType(typeName, fieldName string) (string, bool, bool) {
+	def := s.schema.Types[typeName]
+	if def == nil {
+		return "", false, false
+	}
+	field := def.Fields.ForName(fieldName)
+	if field == nil {
+		return "", false, false
+	}
+	return field.Type.Name(), field.Type.Elem != nil, true
+}
+
+type graphqlGoSchema struct {
+	schema *graphql.Schema
+}
+
+// GraphQLGoSchema adapts a schema built with github.com/graphql-go/graphql.
+func GraphQLGoSchema(schema *graphql.Schema) Schema {
+	return graphqlGoSchema{schema}
+}
+
+func (s graphqlGoSchema) RootType(op ast.Operation) string {
+	var root *graphql.Object
+	switch op {
+	case ast.Query:
+		root = s.schema.QueryType()
+	case ast.Mutation:
+		root = s.schema.MutationType()
+	case ast.Subscription:
+		root = s.schema.SubscriptionType()
+	}
+	if root == nil {
+		return ""
+	}
+	return root.Name()
+}
+
+func (s graphqlGoSchema) FieldType(typeName, fieldName string) (string, bool, bool) {
+	t, ok := s.schema.Type(typeName).(interface {
+		Fields() graphql.FieldDefinitionMap
+	})
+	if !ok {
+		return "", false, false
+	}
+	field := t.Fields()[fieldName]
+	if field == nil {
+		return "", false, false
+	}
+
+	var list bool
+	fieldType := field.Type
+	for {
+		switch t := fieldType.(type) {
+		case *graphql.NonNull:
+			fieldType = t.OfType
+			continue
+		case *graphql.List:
+			list = true
+			fieldType = t.OfType
+			continue
+		}
+		break
+	}
+	return fieldType.Name(), list, true
+}
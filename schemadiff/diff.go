@@ -0,0 +1,348 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// Criticality tells how a change affects the clients of a schema.
+type Criticality string
+
+const (
+	// Breaking changes make valid operations fail.
+	Breaking Criticality = "BREAKING"
+	// Dangerous changes keep operations valid but may change their results.
+	Dangerous Criticality = "DANGEROUS"
+	// Safe changes cannot affect existing clients.
+	Safe Criticality = "SAFE"
+)
+
+// Change is one difference between two versions of a schema.
+type Change struct {
+	Criticality Criticality `json:"criticality"`
+	// Kind names the change, such as FIELD_REMOVED.
+	Kind string `json:"kind"`
+	// Path is the coordinate of the changed element, such as Query.hero or
+	// Query.hero(episode:).
+	Path    string `json:"path"`
+	Message string `json:"message"`
+}
+
+type differ struct {
+	changes []Change
+}
+
+func (d *differ) add(c Criticality, kind, path, format string, args ...interface{}) {
+	d.changes = append(d.changes, Change{c, kind, path, fmt.Sprintf(format, args...)})
+}
+
+// compare returns the changes from the old schema to the new one, sorted by
+// path.
+func compare(old, new *ast.Schema) []Change {
+	d := &differ{}
+	for _, name := range typeNames(old, new) {
+		o, n := old.Types[name], new.Types[name]
+		switch {
+		case n == nil:
+			d.add(Breaking, "TYPE_REMOVED", name, "%s %s was removed", kindName(o.Kind), name)
+		case o == nil:
+			d.add(Safe, "TYPE_ADDED", name, "%s %s was added", kindName(n.Kind), name)
+		case o.Kind != n.Kind:
+			d.add(Breaking, "TYPE_KIND_CHANGED", name, "%s changed from %s to %s", name, kindName(o.Kind), kindName(n.Kind))
+		default:
+			d.definition(o, n)
+		}
+	}
+	for _, name := range directiveNames(old, new) {
+		o, n := old.Directives[name], new.Directives[name]
+		switch {
+		case n == nil:
+			d.add(Breaking, "DIRECTIVE_REMOVED", "@"+name, "directive @%s was removed", name)
+		case o == nil:
+			d.add(Safe, "DIRECTIVE_ADDED", "@"+name, "directive @%s was added", name)
+		default:
+			d.directive(o, n)
+		}
+	}
+	sort.SliceStable(d.changes, func(i, j int) bool { return d.changes[i].Path < d.changes[j].Path })
+	return d.changes
+}
+
+func (d *differ) definition(o, n *ast.Definition) {
+	if o.Description != n.Description {
+		d.add(Safe, "DESCRIPTION_CHANGED", n.Name, "description of %s changed", n.Name)
+	}
+	switch n.Kind {
+	case ast.Object, ast.Interface:
+		d.members("INTERFACE", n.Name, o.Interfaces, n.Interfaces, "implements %s")
+		d.fields(o, n)
+	case ast.Union:
+		d.members("UNION_MEMBER", n.Name, o.Types, n.Types, "has member %s")
+	case ast.Enum:
+		d.enumValues(o, n)
+	case ast.InputObject:
+		d.inputFields(o, n)
+	}
+}
+
+// members compares the interfaces of an object or the members of a union,
+// where a removal breaks the fragments on them and an addition may reach
+// code that handles a closed set of types.
+func (d *differ) members(kind, path string, old, new []string, verb string) {
+	for _, name := range old {
+		if !contains(new, name) {
+			d.add(Breaking, kind+"_REMOVED", path, "%s no longer "+verb, path, name)
+		}
+	}
+	for _, name := range new {
+		if !contains(old, name) {
+			d.add(Dangerous, kind+"_ADDED", path, "%s now "+verb, path, name)
+		}
+	}
+}
+
+func (d *differ) fields(o, n *ast.Definition) {
+	for _, of := range o.Fields {
+		path := n.Name + "." + of.Name
+		nf := n.Fields.ForName(of.Name)
+		if nf == nil {
+			d.add(Breaking, "FIELD_REMOVED", path, "field %s was removed", path)
+			continue
+		}
+		if !safeOutputChange(of.Type, nf.Type) {
+			d.add(Breaking, "FIELD_TYPE_CHANGED", path, "field %s changed type from %s to %s", path, of.Type, nf.Type)
+		} else if of.Type.String() != nf.Type.String() {
+			d.add(Safe, "FIELD_TYPE_CHANGED", path, "field %s changed type from %s to %s", path, of.Type, nf.Type)
+		}
+		d.deprecation(path, of.Directives, nf.Directives)
+		if of.Description != nf.Description {
+			d.add(Safe, "DESCRIPTION_CHANGED", path, "description of %s changed", path)
+		}
+		d.arguments(path, of.Arguments, nf.Arguments)
+	}
+	for _, nf := range n.Fields {
+		if o.Fields.ForName(nf.Name) == nil {
+			path := n.Name + "." + nf.Name
+			d.add(Safe, "FIELD_ADDED", path, "field %s was added", path)
+		}
+	}
+}
+
+func (d *differ) arguments(parent string, old, new ast.ArgumentDefinitionList) {
+	for _, oa := range old {
+		path := parent + "(" + oa.Name + ":)"
+		na := new.ForName(oa.Name)
+		if na == nil {
+			d.add(Breaking, "ARGUMENT_REMOVED", path, "argument %s was removed", path)
+			continue
+		}
+		if !safeInputChange(oa.Type, na.Type) {
+			d.add(Breaking, "ARGUMENT_TYPE_CHANGED", path, "argument %s changed type from %s to %s", path, oa.Type, na.Type)
+		} else if oa.Type.String() != na.Type.String() {
+			d.add(Safe, "ARGUMENT_TYPE_CHANGED", path, "argument %s changed type from %s to %s", path, oa.Type, na.Type)
+		}
+		if value(oa.DefaultValue) != value(na.DefaultValue) {
+			d.add(Dangerous, "ARGUMENT_DEFAULT_CHANGED", path, "default value of argument %s changed from %s to %s", path, value(oa.DefaultValue), value(na.DefaultValue))
+		}
+		if oa.Description != na.Description {
+			d.add(Safe, "DESCRIPTION_CHANGED", path, "description of %s changed", path)
+		}
+	}
+	for _, na := range new {
+		if old.ForName(na.Name) != nil {
+			continue
+		}
+		path := parent + "(" + na.Name + ":)"
+		if required(na.Type, na.DefaultValue) {
+			d.add(Breaking, "REQUIRED_ARGUMENT_ADDED", path, "required argument %s was added", path)
+		} else {
+			d.add(Dangerous, "OPTIONAL_ARGUMENT_ADDED", path, "optional argument %s was added", path)
+		}
+	}
+}
+
+func (d *differ) inputFields(o, n *ast.Definition) {
+	for _, of := range o.Fields {
+		path := n.Name + "." + of.Name
+		nf := n.Fields.ForName(of.Name)
+		if nf == nil {
+			d.add(Breaking, "INPUT_FIELD_REMOVED", path, "input field %s was removed", path)
+			continue
+		}
+		if !safeInputChange(of.Type, nf.Type) {
+			d.add(Breaking, "INPUT_FIELD_TYPE_CHANGED", path, "input field %s changed type from %s to %s", path, of.Type, nf.Type)
+		} else if of.Type.String() != nf.Type.String() {
+			d.add(Safe, "INPUT_FIELD_TYPE_CHANGED", path, "input field %s changed type from %s to %s", path, of.Type, nf.Type)
+		}
+		if value(of.DefaultValue) != value(nf.DefaultValue) {
+			d.add(Dangerous, "INPUT_FIELD_DEFAULT_CHANGED", path, "default value of input field %s changed from %s to %s", path, value(of.DefaultValue), value(nf.DefaultValue))
+		}
+		if of.Description != nf.Description {
+			d.add(Safe, "DESCRIPTION_CHANGED", path, "description of %s changed", path)
+		}
+	}
+	for _, nf := range n.Fields {
+		if o.Fields.ForName(nf.Name) != nil {
+			continue
+		}
+		path := n.Name + "." + nf.Name
+		if required(nf.Type, nf.DefaultValue) {
+			d.add(Breaking, "REQUIRED_INPUT_FIELD_ADDED", path, "required input field %s was added", path)
+		} else {
+			d.add(Dangerous, "OPTIONAL_INPUT_FIELD_ADDED", path, "optional input field %s was added", path)
+		}
+	}
+}
+
+func (d *differ) enumValues(o, n *ast.Definition) {
+	for _, ov := range o.EnumValues {
+		path := n.Name + "." + ov.Name
+		nv := n.EnumValues.ForName(ov.Name)
+		if nv == nil {
+			d.add(Breaking, "ENUM_VALUE_REMOVED", path, "enum value %s was removed", path)
+			continue
+		}
+		d.deprecation(path, ov.Directives, nv.Directives)
+		if ov.Description != nv.Description {
+			d.add(Safe, "DESCRIPTION_CHANGED", path, "description of %s changed", path)
+		}
+	}
+	for _, nv := range n.EnumValues {
+		if o.EnumValues.ForName(nv.Name) == nil {
+			path := n.Name + "." + nv.Name
+			d.add(Dangerous, "ENUM_VALUE_ADDED", path, "enum value %s was added", path)
+		}
+	}
+}
+
+func (d *differ) deprecation(path string, old, new ast.DirectiveList) {
+	o, n := old.ForName("deprecated") != nil, new.ForName("deprecated") != nil
+	switch {
+	case !o && n:
+		d.add(Safe, "DEPRECATION_ADDED", path, "%s was deprecated", path)
+	case o && !n:
+		d.add(Safe, "DEPRECATION_REMOVED", path, "%s is no longer deprecated", path)
+	}
+}
+
+func (d *differ) directive(o, n *ast.DirectiveDefinition) {
+	path := "@" + n.Name
+	for _, loc := range o.Locations {
+		if !containsLocation(n.Locations, loc) {
+			d.add(Breaking, "DIRECTIVE_LOCATION_REMOVED", path, "directive %s can no longer be used on %s", path, loc)
+		}
+	}
+	for _, loc := range n.Locations {
+		if !containsLocation(o.Locations, loc) {
+			d.add(Safe, "DIRECTIVE_LOCATION_ADDED", path, "directive %s can now be used on %s", path, loc)
+		}
+	}
+	d.arguments(path, o.Arguments, n.Arguments)
+}
+
+// safeOutputChange tells whether the type of a field can change from old to
+// new without breaking clients: it may only become non-null.
+func safeOutputChange(old, new *ast.Type) bool {
+	if old.NonNull && !new.NonNull {
+		return false
+	}
+	if old.Elem != nil || new.Elem != nil {
+		return old.Elem != nil && new.Elem != nil && safeOutputChange(old.Elem, new.Elem)
+	}
+	return old.NamedType == new.NamedType
+}
+
+// safeInputChange tells whether the type of an argument or input field can
+// change from old to new without breaking clients: it may only become
+// nullable.
+func safeInputChange(old, new *ast.Type) bool {
+	if !old.NonNull && new.NonNull {
+		return false
+	}
+	if old.Elem != nil || new.Elem != nil {
+		return old.Elem != nil && new.Elem != nil && safeInputChange(old.Elem, new.Elem)
+	}
+	return old.NamedType == new.NamedType
+}
+
+// required tells whether an argument or input field must be given.
+func required(t *ast.Type, defaultValue *ast.Value) bool {
+	return t.NonNull && defaultValue == nil
+}
+
+func value(v *ast.Value) string {
+	if v == nil {
+		return "none"
+	}
+	return v.String()
+}
+
+func kindName(k ast.DefinitionKind) string {
+	switch k {
+	case ast.Scalar:
+		return "scalar"
+	case ast.Object:
+		return "type"
+	case ast.Interface:
+		return "interface"
+	case ast.Union:
+		return "union"
+	case ast.Enum:
+		return "enum"
+	case ast.InputObject:
+		return "input"
+	}
+	return string(k)
+}
+
+// typeNames returns the names of the types of either schema, leaving out
+// those built into GraphQL.
+func typeNames(old, new *ast.Schema) []string {
+	seen := map[string]bool{}
+	var names []string
+	for _, s := range []*ast.Schema{old, new} {
+		for name, def := range s.Types {
+			if !def.BuiltIn && !seen[name] {
+				seen[name] = true
+				names = append(names, name)
+			}
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+func directiveNames(old, new *ast.Schema) []string {
+	seen := map[string]bool{}
+	var names []string
+	for _, s := range []*ast.Schema{old, new} {
+		for name := range s.Directives {
+			if !seen[name] {
+				seen[name] = true
+				names = append(names, name)
+			}
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+func contains(l []string, s string) bool {
+	for _, x := range l {
+		if x == s {
+			return true
+		}
+	}
+	return false
+}
+
+func containsLocation(l []ast.DirectiveLocation, loc ast.DirectiveLocation) bool {
+	for _, x := range l {
+		if x == loc {
+			return true
+		}
+	}
+	return false
+}
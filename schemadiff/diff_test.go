@@ -0,0 +1,137 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/vektah/gqlparser/v2"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+func mustLoad(t *testing.T, sdl string) *ast.Schema {
+	t.Helper()
+	schema, err := gqlparser.LoadSchema(&ast.Source{Input: sdl})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return schema
+}
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		name     string
+		old, new string
+		// want are the changes expected, as criticality, kind and path.
+		want []string
+	}{
+		{
+			name: "same",
+			old:  `type Query { hero: String }`,
+			new:  `type Query { hero: String }`,
+		},
+		{
+			name: "types",
+			old:  `type Query { hero: String } type Droid { id: ID } scalar Time`,
+			new:  `type Query { hero: String } type Human { id: ID } enum Time { NOW }`,
+			want: []string{
+				"BREAKING TYPE_REMOVED Droid",
+				"SAFE TYPE_ADDED Human",
+				"BREAKING TYPE_KIND_CHANGED Time",
+			},
+		},
+		{
+			name: "fields",
+			old:  `type Query { hero: String name: String! friends: [String] age: Int old: Int }`,
+			new: `"The root" type Query { hero: String! name: String friends: [String!]! age: Float
+				old: Int @deprecated added: Int }`,
+			want: []string{
+				"SAFE DESCRIPTION_CHANGED Query",
+				"SAFE FIELD_ADDED Query.added",
+				"BREAKING FIELD_TYPE_CHANGED Query.age",
+				"SAFE FIELD_TYPE_CHANGED Query.friends",
+				"SAFE FIELD_TYPE_CHANGED Query.hero",
+				"BREAKING FIELD_TYPE_CHANGED Query.name",
+				"SAFE DEPRECATION_ADDED Query.old",
+			},
+		},
+		{
+			name: "removed field",
+			old:  `type Query { hero: String name: String }`,
+			new:  `type Query { hero: String }`,
+			want: []string{"BREAKING FIELD_REMOVED Query.name"},
+		},
+		{
+			name: "arguments",
+			old:  `type Query { hero(a: Int!, b: Int, c: Int = 1, d: Int): String }`,
+			new:  `type Query { hero(a: Int, b: Int!, c: Int = 2, e: Int, f: Int!, g: Int! = 0): String }`,
+			want: []string{
+				"SAFE ARGUMENT_TYPE_CHANGED Query.hero(a:)",
+				"BREAKING ARGUMENT_TYPE_CHANGED Query.hero(b:)",
+				"DANGEROUS ARGUMENT_DEFAULT_CHANGED Query.hero(c:)",
+				"BREAKING ARGUMENT_REMOVED Query.hero(d:)",
+				"DANGEROUS OPTIONAL_ARGUMENT_ADDED Query.hero(e:)",
+				"BREAKING REQUIRED_ARGUMENT_ADDED Query.hero(f:)",
+				"DANGEROUS OPTIONAL_ARGUMENT_ADDED Query.hero(g:)",
+			},
+		},
+		{
+			name: "input fields",
+			old:  `type Query { hero(f: Filter): String } input Filter { a: Int! b: Int c: Int }`,
+			new:  `type Query { hero(f: Filter): String } input Filter { a: Int b: Int = 3 d: Int e: Int! }`,
+			want: []string{
+				"SAFE INPUT_FIELD_TYPE_CHANGED Filter.a",
+				"DANGEROUS INPUT_FIELD_DEFAULT_CHANGED Filter.b",
+				"BREAKING INPUT_FIELD_REMOVED Filter.c",
+				"DANGEROUS OPTIONAL_INPUT_FIELD_ADDED Filter.d",
+				"BREAKING REQUIRED_INPUT_FIELD_ADDED Filter.e",
+			},
+		},
+		{
+			name: "enum values",
+			old:  `type Query { hero(e: Episode): String } enum Episode { NEWHOPE EMPIRE JEDI @deprecated }`,
+			new:  `type Query { hero(e: Episode): String } enum Episode { "First" NEWHOPE JEDI CLONES }`,
+			want: []string{
+				"DANGEROUS ENUM_VALUE_ADDED Episode.CLONES",
+				"BREAKING ENUM_VALUE_REMOVED Episode.EMPIRE",
+				"SAFE DEPRECATION_REMOVED Episode.JEDI",
+				"SAFE DESCRIPTION_CHANGED Episode.NEWHOPE",
+			},
+		},
+		{
+			name: "interfaces and unions",
+			old: `type Query { s: Search } interface Node { id: ID } interface Named { id: ID }
+				type Droid implements Node { id: ID } type Human { id: ID } union Search = Droid`,
+			new: `type Query { s: Search } interface Node { id: ID } interface Named { id: ID }
+				type Droid implements Named { id: ID } type Human { id: ID } union Search = Human`,
+			want: []string{
+				"BREAKING INTERFACE_REMOVED Droid",
+				"DANGEROUS INTERFACE_ADDED Droid",
+				"BREAKING UNION_MEMBER_REMOVED Search",
+				"DANGEROUS UNION_MEMBER_ADDED Search",
+			},
+		},
+		{
+			name: "directives",
+			old:  `type Query { hero: String } directive @a on FIELD | QUERY directive @b on FIELD`,
+			new:  `type Query { hero: String } directive @a(x: Int!) on FIELD | MUTATION directive @c on FIELD`,
+			want: []string{
+				"BREAKING DIRECTIVE_LOCATION_REMOVED @a",
+				"SAFE DIRECTIVE_LOCATION_ADDED @a",
+				"BREAKING REQUIRED_ARGUMENT_ADDED @a(x:)",
+				"BREAKING DIRECTIVE_REMOVED @b",
+				"SAFE DIRECTIVE_ADDED @c",
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var got []string
+			for _, c := range compare(mustLoad(t, test.old), mustLoad(t, test.new)) {
+				got = append(got, string(c.Criticality)+" "+c.Kind+" "+c.Path)
+			}
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("got changes\n%q\nwant\n%q", got, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,109 @@
+// Command schemadiff compares two versions of an SDL schema and classifies
+// each change as breaking, dangerous or safe. It exits with status 1 when a
+// change is breaking, so that it can gate schema edits.
+//
+// Each schema is a file, or a git revision and path separated by a colon to
+// read the file as of that revision:
+//
+//	go run ./schemadiff main:gqlgen-starwar/schema.graphql gqlgen-starwar/schema.graphql
+//	go run ./schemadiff -json old.graphql new.graphql > report.json
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/vektah/gqlparser/v2"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+var jsonReport = flag.Bool("json", false, "write the report as JSON")
+
+// report is the JSON report of a comparison.
+type report struct {
+	Old       string   `json:"old"`
+	New       string   `json:"new"`
+	Breaking  int      `json:"breaking"`
+	Dangerous int      `json:"dangerous"`
+	Safe      int      `json:"safe"`
+	Changes   []Change `json:"changes"`
+}
+
+func main() {
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: schemadiff [-json] old new\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	log.SetFlags(0)
+	if flag.NArg() != 2 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	old, err := load(flag.Arg(0))
+	if err != nil {
+		log.Fatal(err)
+	}
+	new, err := load(flag.Arg(1))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	r := report{Old: flag.Arg(0), New: flag.Arg(1), Changes: compare(old, new)}
+	if r.Changes == nil {
+		r.Changes = []Change{}
+	}
+	for _, c := range r.Changes {
+		switch c.Criticality {
+		case Breaking:
+			r.Breaking++
+		case Dangerous:
+			r.Dangerous++
+		case Safe:
+			r.Safe++
+		}
+	}
+
+	if *jsonReport {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(r); err != nil {
+			log.Fatal(err)
+		}
+	} else {
+		for _, c := range r.Changes {
+			fmt.Printf("%-9s %s\n", c.Criticality, c.Message)
+		}
+		fmt.Printf("%d breaking, %d dangerous, %d safe changes\n", r.Breaking, r.Dangerous, r.Safe)
+	}
+	if r.Breaking > 0 {
+		os.Exit(1)
+	}
+}
+
+// load parses the schema named by arg, a file or a revision:path to read
+// with git.
+func load(arg string) (*ast.Schema, error) {
+	b, err := ioutil.ReadFile(arg)
+	if os.IsNotExist(err) && strings.Contains(arg, ":") {
+		b, err = exec.Command("git", "show", arg).Output()
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			err = fmt.Errorf("git show %s: %s", arg, strings.TrimSpace(string(exitErr.Stderr)))
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	schema, gqlErr := gqlparser.LoadSchema(&ast.Source{Name: arg, Input: string(b)})
+	if gqlErr != nil {
+		return nil, gqlErr
+	}
+	return schema, nil
+}
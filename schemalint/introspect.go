@@ -0,0 +1,225 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"graphql/graphql-starwar/exec"
+	"strings"
+
+	"github.com/graphql-go/graphql"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+const introspectionQuery = `
+query IntrospectionQuery {
+  __schema {
+    queryType { name }
+    mutationType { name }
+    subscriptionType { name }
+    types {
+      kind name description
+      fields(includeDeprecated: true) {
+        name description isDeprecated deprecationReason
+        args { ...InputValue }
+        type { ...TypeRef }
+      }
+      inputFields { ...InputValue }
+      interfaces { name }
+      enumValues(includeDeprecated: true) { name description isDeprecated deprecationReason }
+      possibleTypes { name }
+    }
+  }
+}
+fragment InputValue on __InputValue {
+  name description defaultValue
+  type { ...TypeRef }
+}
+fragment TypeRef on __Type {
+  kind name
+  ofType { kind name ofType { kind name ofType { kind name ofType { kind name ofType { kind name } } } } }
+}`
+
+type introspection struct {
+	Schema struct {
+		QueryType        *typeRef
+		MutationType     *typeRef
+		SubscriptionType *typeRef
+		Types            []struct {
+			Kind          string
+			Name          string
+			Description   string
+			Fields        []introspectedField
+			InputFields   []inputValue
+			Interfaces    []typeRef
+			EnumValues    []introspectedEnumValue
+			PossibleTypes []typeRef
+		}
+	} `json:"__schema"`
+}
+
+type introspectedField struct {
+	Name              string
+	Description       string
+	IsDeprecated      bool
+	DeprecationReason string
+	Args              []inputValue
+	Type              typeRef
+}
+
+type introspectedEnumValue struct {
+	Name              string
+	Description       string
+	IsDeprecated      bool
+	DeprecationReason string
+}
+
+type inputValue struct {
+	Name         string
+	Description  string
+	DefaultValue *string
+	Type         typeRef
+}
+
+type typeRef struct {
+	Kind   string
+	Name   string
+	OfType *typeRef
+}
+
+// introspectStarWars reads the schema of graphql-starwar, which is built in
+// code, with an introspection query.
+func introspectStarWars() (*ast.Schema, error) {
+	result := graphql.Do(graphql.Params{Schema: exec.StarWarsSchema, RequestString: introspectionQuery})
+	if len(result.Errors) > 0 {
+		return nil, fmt.Errorf("introspecting graphql-starwar: %v", result.Errors[0])
+	}
+	b, err := json.Marshal(result.Data)
+	if err != nil {
+		return nil, err
+	}
+	var i introspection
+	if err := json.Unmarshal(b, &i); err != nil {
+		return nil, err
+	}
+	return i.schema(), nil
+}
+
+// schema builds the schema described by the introspection result. Default
+// values are kept as their raw literal, which the rules do not look into.
+func (i *introspection) schema() *ast.Schema {
+	s := &ast.Schema{
+		Types:         map[string]*ast.Definition{},
+		Directives:    map[string]*ast.DirectiveDefinition{},
+		PossibleTypes: map[string][]*ast.Definition{},
+		Implements:    map[string][]*ast.Definition{},
+	}
+	for _, t := range i.Schema.Types {
+		def := &ast.Definition{
+			Kind:        ast.DefinitionKind(t.Kind),
+			Name:        t.Name,
+			Description: t.Description,
+			BuiltIn:     strings.HasPrefix(t.Name, "__") || builtinScalars[t.Name],
+		}
+		for _, f := range t.Fields {
+			field := &ast.FieldDefinition{
+				Name:        f.Name,
+				Description: f.Description,
+				Type:        f.Type.ast(),
+				Directives:  deprecation(f.IsDeprecated, f.DeprecationReason),
+			}
+			for _, a := range f.Args {
+				field.Arguments = append(field.Arguments, a.argument())
+			}
+			def.Fields = append(def.Fields, field)
+		}
+		for _, f := range t.InputFields {
+			a := f.argument()
+			def.Fields = append(def.Fields, &ast.FieldDefinition{
+				Name:         a.Name,
+				Description:  a.Description,
+				DefaultValue: a.DefaultValue,
+				Type:         a.Type,
+			})
+		}
+		for _, iface := range t.Interfaces {
+			def.Interfaces = append(def.Interfaces, iface.Name)
+		}
+		for _, v := range t.EnumValues {
+			def.EnumValues = append(def.EnumValues, &ast.EnumValueDefinition{
+				Name:        v.Name,
+				Description: v.Description,
+				Directives:  deprecation(v.IsDeprecated, v.DeprecationReason),
+			})
+		}
+		if def.Kind == ast.Union {
+			for _, member := range t.PossibleTypes {
+				def.Types = append(def.Types, member.Name)
+			}
+		}
+		s.Types[def.Name] = def
+	}
+	for _, def := range s.Types {
+		for _, name := range def.Interfaces {
+			s.AddImplements(name, def)
+			s.AddPossibleType(name, def)
+		}
+		for _, name := range def.Types {
+			s.AddPossibleType(def.Name, s.Types[name])
+		}
+	}
+	if i.Schema.QueryType != nil {
+		s.Query = s.Types[i.Schema.QueryType.Name]
+	}
+	if i.Schema.MutationType != nil {
+		s.Mutation = s.Types[i.Schema.MutationType.Name]
+	}
+	if i.Schema.SubscriptionType != nil {
+		s.Subscription = s.Types[i.Schema.SubscriptionType.Name]
+	}
+	return s
+}
+
+var builtinScalars = map[string]bool{
+	"String":  true,
+	"Int":     true,
+	"Float":   true,
+	"Boolean": true,
+	"ID":      true,
+}
+
+func (v inputValue) argument() *ast.ArgumentDefinition {
+	a := &ast.ArgumentDefinition{
+		Name:        v.Name,
+		Description: v.Description,
+		Type:        v.Type.ast(),
+	}
+	if v.DefaultValue != nil {
+		a.DefaultValue = &ast.Value{Raw: *v.DefaultValue, Kind: ast.EnumValue}
+	}
+	return a
+}
+
+func (t typeRef) ast() *ast.Type {
+	switch t.Kind {
+	case "NON_NULL":
+		inner := t.OfType.ast()
+		inner.NonNull = true
+		return inner
+	case "LIST":
+		return ast.ListType(t.OfType.ast(), nil)
+	}
+	return ast.NamedType(t.Name, nil)
+}
+
+func deprecation(deprecated bool, reason string) ast.DirectiveList {
+	if !deprecated {
+		return nil
+	}
+	return ast.DirectiveList{{
+		Name: "deprecated",
+		Arguments: ast.ArgumentList{{
+			Name:  "reason",
+			Value: &ast.Value{Raw: reason, Kind: ast.StringValue},
+		}},
+	}}
+}
@@ -0,0 +1,192 @@
+// Command schemalint checks SDL schemas against the conventions of the
+// project, and exits with status 1 when it finds an error.
+//
+// Each rule has a default severity that can be changed, or set to off, in
+// a JSON config file mapping rule names to severities, or with -rule:
+//
+//	go run ./schemalint gophers-starwar/schema.graphql gqlgen-starwar/schema.graphql
+//	go run ./schemalint -graphql-go -rule descriptions=error
+//	go run ./schemalint -rules   # list the rules
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/vektah/gqlparser/v2"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// Severity is the level of a problem.
+type Severity string
+
+const (
+	Error   Severity = "error"
+	Warning Severity = "warning"
+	Off     Severity = "off"
+)
+
+var (
+	config    = flag.String("config", "", "JSON file mapping rule names to severities")
+	graphqlGo = flag.Bool("graphql-go", false, "also lint the schema of graphql-starwar, read by introspection")
+	listRules = flag.Bool("rules", false, "list the rules and their default severities")
+	overrides = severities{}
+)
+
+func init() {
+	flag.Var(overrides, "rule", "severity of a rule as name=error|warning|off, may be repeated")
+}
+
+// problem is a violation of a rule.
+type problem struct {
+	source   string
+	severity Severity
+	rule     string
+	path     string
+	message  string
+}
+
+func main() {
+	flag.Parse()
+	log.SetFlags(0)
+
+	if *listRules {
+		for _, r := range rules {
+			fmt.Printf("%-20s %-8s %s\n", r.Name, r.Severity, r.Description)
+		}
+		return
+	}
+	if *config != "" {
+		if err := loadConfig(*config); err != nil {
+			log.Fatal(err)
+		}
+	}
+	if flag.NArg() == 0 && !*graphqlGo {
+		log.Fatal("usage: schemalint [flags] [schema.graphql ...]")
+	}
+
+	var problems []problem
+	for _, file := range flag.Args() {
+		schema, err := loadSDL(file)
+		if err != nil {
+			log.Fatal(err)
+		}
+		problems = append(problems, lint(file, schema)...)
+	}
+	if *graphqlGo {
+		schema, err := introspectStarWars()
+		if err != nil {
+			log.Fatal(err)
+		}
+		problems = append(problems, lint("graphql-starwar", schema)...)
+	}
+
+	errors := 0
+	for _, p := range problems {
+		if p.severity == Error {
+			errors++
+		}
+		fmt.Printf("%s: %s: %s: %s %s\n", p.source, p.severity, p.rule, p.path, p.message)
+	}
+	fmt.Printf("%d errors, %d warnings\n", errors, len(problems)-errors)
+	if errors > 0 {
+		os.Exit(1)
+	}
+}
+
+// lint runs the rules that are not off over schema, read from source.
+func lint(source string, schema *ast.Schema) []problem {
+	var problems []problem
+	for _, r := range rules {
+		s := r.Severity
+		if override, ok := overrides[r.Name]; ok {
+			s = override
+		}
+		if s == Off {
+			continue
+		}
+		r.Check(schema, func(path, format string, args ...interface{}) {
+			problems = append(problems, problem{source, s, r.Name, path, fmt.Sprintf(format, args...)})
+		})
+	}
+	sort.SliceStable(problems, func(i, j int) bool { return problems[i].path < problems[j].path })
+	return problems
+}
+
+func loadSDL(file string) (*ast.Schema, error) {
+	b, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	schema, gqlErr := gqlparser.LoadSchema(&ast.Source{Name: file, Input: string(b)})
+	if gqlErr != nil {
+		return nil, gqlErr
+	}
+	return schema, nil
+}
+
+// loadConfig reads the severities of a config file, which those given with
+// -rule take precedence over.
+func loadConfig(file string) error {
+	b, err := ioutil.ReadFile(file)
+	if err != nil {
+		return err
+	}
+	var c map[string]Severity
+	if err := json.Unmarshal(b, &c); err != nil {
+		return fmt.Errorf("%s: %v", file, err)
+	}
+	for name, s := range c {
+		if _, ok := overrides[name]; ok {
+			continue
+		}
+		if err := overrides.set(name, s); err != nil {
+			return fmt.Errorf("%s: %v", file, err)
+		}
+	}
+	return nil
+}
+
+// severities are the severities of rules set by the user, by rule name.
+type severities map[string]Severity
+
+func (s severities) String() string {
+	var l []string
+	for name, severity := range s {
+		l = append(l, name+"="+string(severity))
+	}
+	sort.Strings(l)
+	return strings.Join(l, ",")
+}
+
+func (s severities) Set(v string) error {
+	parts := strings.SplitN(v, "=", 2)
+	if len(parts) != 2 {
+		return fmt.Errorf("rule %q is not in the form name=severity", v)
+	}
+	return s.set(parts[0], Severity(parts[1]))
+}
+
+func (s severities) set(name string, severity Severity) error {
+	known := false
+	for _, r := range rules {
+		if r.Name == name {
+			known = true
+		}
+	}
+	if !known {
+		return fmt.Errorf("unknown rule %q", name)
+	}
+	switch severity {
+	case Error, Warning, Off:
+		s[name] = severity
+		return nil
+	}
+	return fmt.Errorf("unknown severity %q for rule %s", severity, name)
+}
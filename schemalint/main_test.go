@@ -0,0 +1,173 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/vektah/gqlparser/v2"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+func TestRules(t *testing.T) {
+	tests := []struct {
+		rule string
+		sdl  string
+		// want are the paths of the problems expected.
+		want []string
+	}{
+		{
+			rule: "descriptions",
+			sdl: `"The root" type Query {
+				"A hero" hero("Its film" episode: Episode): String
+				# A villain
+				villain: String
+			}
+			"A film" enum Episode { "First" NEWHOPE EMPIRE }`,
+			want: []string{"Episode.EMPIRE", "Query.villain"},
+		},
+		{
+			rule: "copied-descriptions",
+			sdl: `type Query {
+				"A hero" hero("An id" id: ID, "An id" other: ID): String
+				"A hero" villain: String
+				a: String
+				b: String
+			}`,
+			want: []string{"Query.hero(other:)", "Query.villain"},
+		},
+		{
+			rule: "naming",
+			sdl:  `type Query { hero(Episode: Int): String villain_name: String } type lowerType { id: ID }`,
+			want: []string{"Query.hero(Episode:)", "Query.villain_name", "lowerType"},
+		},
+		{
+			rule: "relay-connections",
+			sdl: `type Query {
+				ships(first: Int, after: String): ShipConnection
+				bad(first: String!, before: String!): ShipConnection
+				list: [ShipConnection]
+			}
+			type ShipConnection { edges: [ShipEdge] pageInfo: PageInfo }
+			type ShipEdge { node: [String] cursor: String }
+			type PageInfo { hasNextPage: Boolean! hasPreviousPage: Boolean startCursor: String endCursor: String! }`,
+			want: []string{
+				"PageInfo.endCursor",
+				"PageInfo.hasPreviousPage",
+				"Query.bad",
+				"Query.bad(before:)",
+				"Query.bad(first:)",
+				"ShipConnection.pageInfo",
+				"ShipEdge.cursor",
+				"ShipEdge.node",
+			},
+		},
+		{
+			rule: "relay-connections",
+			sdl: `type Query { ships: ShipConnection } type ShipConnection { edges: ShipEdge pageInfo: PageInfo! } type ShipEdge { node: String }
+			type PageInfo { hasNextPage: Boolean! hasPreviousPage: Boolean! startCursor: String endCursor: String }`,
+			want: []string{"Query.ships", "ShipConnection.edges"},
+		},
+		{
+			rule: "input-naming",
+			sdl:  `type Query { hero(a: Filter, b: FilterInput): HeroInput } input Filter { a: Int } input FilterInput { a: Int } type HeroInput { a: Int }`,
+			want: []string{"Filter", "HeroInput"},
+		},
+		{
+			rule: "enum-casing",
+			sdl:  `type Query { hero(e: Episode): String } enum Episode { NEW_HOPE Empire JEDI_6 jedi }`,
+			want: []string{"Episode.Empire", "Episode.jedi"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.rule, func(t *testing.T) {
+			schema, err := gqlparser.LoadSchema(&ast.Source{Input: test.sdl})
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for _, p := range lint("test", schema) {
+				if p.rule == test.rule {
+					got = append(got, p.path)
+				}
+			}
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("got problems at %q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestSeverities(t *testing.T) {
+	defer func() { overrides = severities{} }()
+	schema, err := gqlparser.LoadSchema(&ast.Source{Input: `type Query { hero_name: String }`})
+	if err != nil {
+		t.Fatal(err)
+	}
+	severity := func(rule string) Severity {
+		for _, p := range lint("test", schema) {
+			if p.rule == rule {
+				return p.severity
+			}
+		}
+		return Off
+	}
+	if got := severity("naming"); got != Error {
+		t.Errorf("got naming %s by default, want error", got)
+	}
+	if got := severity("descriptions"); got != Warning {
+		t.Errorf("got descriptions %s by default, want warning", got)
+	}
+
+	for _, v := range []string{"naming", "naming=fatal", "unknown=off"} {
+		if err := overrides.Set(v); err == nil {
+			t.Errorf("-rule %s was accepted", v)
+		}
+	}
+	if err := overrides.Set("naming=warning"); err != nil {
+		t.Fatal(err)
+	}
+	config := filepath.Join(t.TempDir(), "lint.json")
+	if err := os.WriteFile(config, []byte(`{"naming": "off", "descriptions": "off"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := loadConfig(config); err != nil {
+		t.Fatal(err)
+	}
+	if got := severity("naming"); got != Warning {
+		t.Errorf("got naming %s, want the warning given with -rule over the config", got)
+	}
+	if got := severity("descriptions"); got != Off {
+		t.Errorf("got descriptions %s, want it off by the config", got)
+	}
+}
+
+// TestProjectSchemas keeps the schemas of the project free of errors.
+func TestProjectSchemas(t *testing.T) {
+	for _, file := range []string{
+		"../gophers-starwar/schema.graphql",
+		"../gqlgen-starwar/schema.graphql",
+		"../gqlgen/graph/schema.graphqls",
+	} {
+		schema, err := loadSDL(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		checkErrors(t, lint(file, schema))
+	}
+	schema, err := introspectStarWars()
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkErrors(t, lint("graphql-starwar", schema))
+}
+
+func checkErrors(t *testing.T, problems []problem) {
+	t.Helper()
+	for _, p := range problems {
+		if p.severity == Error {
+			t.Errorf("%s: %s: %s %s", p.source, p.rule, p.path, p.message)
+		}
+	}
+}
@@ -0,0 +1,283 @@
+package main
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// Rule checks one convention of a schema.
+type Rule struct {
+	Name        string
+	Description string
+	// Severity is the default severity of the problems found by the rule.
+	Severity Severity
+	// Check calls report for every problem of schema, with the coordinate of
+	// the element at fault, such as Query.hero or Query.hero(episode:).
+	Check func(schema *ast.Schema, report func(path, format string, args ...interface{}))
+}
+
+// rules are the rules of the linter. Add a Rule here to plug in a new one.
+var rules = []Rule{
+	{
+		Name:        "descriptions",
+		Description: "types, fields, arguments and enum values have a description, not a # comment",
+		Severity:    Warning,
+		Check:       checkDescriptions,
+	},
+	{
+		Name:        "copied-descriptions",
+		Description: "fields of a type, or arguments of a field, do not share a description",
+		Severity:    Warning,
+		Check:       checkCopiedDescriptions,
+	},
+	{
+		Name:        "naming",
+		Description: "types are PascalCase, fields and arguments camelCase",
+		Severity:    Error,
+		Check:       checkNaming,
+	},
+	{
+		Name:        "relay-connections",
+		Description: "connection types follow the Relay cursor connections specification",
+		Severity:    Error,
+		Check:       checkConnections,
+	},
+	// Renaming an input type breaks the clients that declare variables of
+	// it, so the older inputs are reported without failing the lint.
+	{
+		Name:        "input-naming",
+		Description: "input types, and only input types, are suffixed with Input",
+		Severity:    Warning,
+		Check:       checkInputNaming,
+	},
+	{
+		Name:        "enum-casing",
+		Description: "enum values are UPPER_CASE",
+		Severity:    Error,
+		Check:       checkEnumCasing,
+	},
+}
+
+var (
+	pascalCase = regexp.MustCompile(`^[A-Z][a-zA-Z0-9]*$`)
+	camelCase  = regexp.MustCompile(`^[a-z][a-zA-Z0-9]*$`)
+	upperCase  = regexp.MustCompile(`^[A-Z][A-Z0-9]*(_[A-Z0-9]+)*$`)
+)
+
+// definitions returns the types of schema that are not built into GraphQL,
+// by name.
+func definitions(schema *ast.Schema) []*ast.Definition {
+	var defs []*ast.Definition
+	for _, def := range schema.Types {
+		if !def.BuiltIn {
+			defs = append(defs, def)
+		}
+	}
+	sort.Slice(defs, func(i, j int) bool { return defs[i].Name < defs[j].Name })
+	return defs
+}
+
+// fields returns the fields of def, leaving out the introspection fields
+// added to the query type.
+func fields(def *ast.Definition) ast.FieldList {
+	var l ast.FieldList
+	for _, f := range def.Fields {
+		if !strings.HasPrefix(f.Name, "__") {
+			l = append(l, f)
+		}
+	}
+	return l
+}
+
+// element is a described part of a schema.
+type element struct {
+	path        string
+	description string
+}
+
+// elements returns the types, fields, arguments, input fields and enum
+// values of schema.
+func elements(schema *ast.Schema) []element {
+	var l []element
+	for _, def := range definitions(schema) {
+		l = append(l, element{def.Name, def.Description})
+		for _, f := range fields(def) {
+			path := def.Name + "." + f.Name
+			l = append(l, element{path, f.Description})
+			for _, a := range f.Arguments {
+				l = append(l, element{path + "(" + a.Name + ":)", a.Description})
+			}
+		}
+		for _, v := range def.EnumValues {
+			l = append(l, element{def.Name + "." + v.Name, v.Description})
+		}
+	}
+	return l
+}
+
+func checkDescriptions(schema *ast.Schema, report func(string, string, ...interface{})) {
+	for _, e := range elements(schema) {
+		if strings.TrimSpace(e.description) == "" {
+			report(e.path, "has no description")
+		}
+	}
+}
+
+// checkCopiedDescriptions reports fields of a type, or arguments of a field,
+// that share a description, which is usually pasted from a neighbour and
+// not updated.
+func checkCopiedDescriptions(schema *ast.Schema, report func(string, string, ...interface{})) {
+	for _, def := range definitions(schema) {
+		described := map[string]string{}
+		for _, f := range fields(def) {
+			path := def.Name + "." + f.Name
+			if other, ok := described[f.Description]; ok && f.Description != "" {
+				report(path, "has the same description as %s: %q", other, f.Description)
+			} else {
+				described[f.Description] = path
+			}
+			args := map[string]string{}
+			for _, a := range f.Arguments {
+				path := path + "(" + a.Name + ":)"
+				if other, ok := args[a.Description]; ok && a.Description != "" {
+					report(path, "has the same description as %s: %q", other, a.Description)
+				} else {
+					args[a.Description] = path
+				}
+			}
+		}
+	}
+}
+
+func checkNaming(schema *ast.Schema, report func(string, string, ...interface{})) {
+	for _, def := range definitions(schema) {
+		if !pascalCase.MatchString(def.Name) {
+			report(def.Name, "type name is not PascalCase")
+		}
+		for _, f := range fields(def) {
+			path := def.Name + "." + f.Name
+			if !camelCase.MatchString(f.Name) {
+				report(path, "field name is not camelCase")
+			}
+			for _, a := range f.Arguments {
+				if !camelCase.MatchString(a.Name) {
+					report(path+"("+a.Name+":)", "argument name is not camelCase")
+				}
+			}
+		}
+	}
+}
+
+// checkConnections checks the types suffixed with Connection, their edges,
+// the PageInfo type and the pagination arguments of the fields returning a
+// connection, following https://relay.dev/graphql/connections.htm.
+func checkConnections(schema *ast.Schema, report func(string, string, ...interface{})) {
+	for _, def := range definitions(schema) {
+		if def.Kind != ast.Object {
+			continue
+		}
+		if strings.HasSuffix(def.Name, "Connection") {
+			checkConnection(schema, def, report)
+		}
+		for _, f := range fields(def) {
+			if t := schema.Types[f.Type.Name()]; t != nil && f.Type.Elem == nil && strings.HasSuffix(t.Name, "Connection") {
+				checkPaginationArguments(def.Name+"."+f.Name, f.Arguments, report)
+			}
+		}
+	}
+
+	pageInfo := schema.Types["PageInfo"]
+	if pageInfo == nil {
+		return
+	}
+	for _, name := range []string{"hasNextPage", "hasPreviousPage"} {
+		expectField(pageInfo, name, "Boolean!", report)
+	}
+	for _, name := range []string{"startCursor", "endCursor"} {
+		f := pageInfo.Fields.ForName(name)
+		if f == nil {
+			report("PageInfo", "has no %s field", name)
+		} else if f.Type.NonNull || f.Type.Elem != nil {
+			report("PageInfo."+name, "is %s, but must be a nullable cursor as an empty connection has none", f.Type)
+		}
+	}
+}
+
+func checkConnection(schema *ast.Schema, def *ast.Definition, report func(string, string, ...interface{})) {
+	expectField(def, "pageInfo", "PageInfo!", report)
+	edges := def.Fields.ForName("edges")
+	if edges == nil {
+		report(def.Name, "has no edges field")
+		return
+	}
+	if edges.Type.Elem == nil {
+		report(def.Name+".edges", "is %s, but must be a list", edges.Type)
+		return
+	}
+	edge := schema.Types[edges.Type.Name()]
+	if edge == nil || edge.Kind != ast.Object {
+		report(def.Name+".edges", "is %s, but must be a list of objects", edges.Type)
+		return
+	}
+	if f := edge.Fields.ForName("node"); f == nil {
+		report(edge.Name, "has no node field")
+	} else if f.Type.Elem != nil {
+		report(edge.Name+".node", "is %s, but must not be a list", f.Type)
+	}
+	if f := edge.Fields.ForName("cursor"); f == nil {
+		report(edge.Name, "has no cursor field")
+	} else if !f.Type.NonNull || f.Type.Elem != nil {
+		report(edge.Name+".cursor", "is %s, but must be a non-null cursor", f.Type)
+	}
+}
+
+// checkPaginationArguments checks that a field returning a connection can
+// be paginated forward, and that its arguments have the expected types.
+func checkPaginationArguments(path string, args ast.ArgumentDefinitionList, report func(string, string, ...interface{})) {
+	if args.ForName("first") == nil || args.ForName("after") == nil {
+		report(path, "returns a connection but does not take first and after arguments")
+	}
+	for _, name := range []string{"first", "last"} {
+		if a := args.ForName(name); a != nil && a.Type.String() != "Int" {
+			report(path+"("+name+":)", "is %s, but must be Int", a.Type)
+		}
+	}
+	for _, name := range []string{"after", "before"} {
+		if a := args.ForName(name); a != nil && (a.Type.NonNull || a.Type.Elem != nil) {
+			report(path+"("+name+":)", "is %s, but must be a nullable cursor", a.Type)
+		}
+	}
+}
+
+func expectField(def *ast.Definition, name, typ string, report func(string, string, ...interface{})) {
+	f := def.Fields.ForName(name)
+	if f == nil {
+		report(def.Name, "has no %s field", name)
+	} else if f.Type.String() != typ {
+		report(def.Name+"."+name, "is %s, but must be %s", f.Type, typ)
+	}
+}
+
+func checkInputNaming(schema *ast.Schema, report func(string, string, ...interface{})) {
+	for _, def := range definitions(schema) {
+		suffixed := strings.HasSuffix(def.Name, "Input")
+		if def.Kind == ast.InputObject && !suffixed {
+			report(def.Name, "input type name is not suffixed with Input")
+		} else if def.Kind != ast.InputObject && suffixed {
+			report(def.Name, "only input type names are suffixed with Input")
+		}
+	}
+}
+
+func checkEnumCasing(schema *ast.Schema, report func(string, string, ...interface{})) {
+	for _, def := range definitions(schema) {
+		for _, v := range def.EnumValues {
+			if !upperCase.MatchString(v.Name) {
+				report(def.Name+"."+v.Name, "enum value is not UPPER_CASE")
+			}
+		}
+	}
+}
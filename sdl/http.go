@@ -0,0 +1,15 @@
+package sdl
+
+import (
+	"net/http"
+
+	"github.com/graphql-go/graphql"
+)
+
+// Handler serves the SDL of schema as plain text.
+func Handler(schema *graphql.Schema) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		w.Write([]byte(Print(schema)))
+	})
+}
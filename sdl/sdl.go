@@ -0,0 +1,383 @@
+// Package sdl prints schemas built in code with github.com/graphql-go/graphql
+// in the schema definition language.
+//
+// The output is canonical: types are printed in order of kind then name,
+// fields, arguments, enum values, interfaces and union members by name, so
+// that two schemas can be compared with a plain diff whatever the order
+// they were built in.
+package sdl
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/graphql-go/graphql"
+)
+
+// builtinScalars are the scalars every schema has, which are not printed.
+var builtinScalars = map[string]bool{
+	"String":  true,
+	"Int":     true,
+	"Float":   true,
+	"Boolean": true,
+	"ID":      true,
+}
+
+// Print returns the SDL of schema.
+func Print(schema *graphql.Schema) string {
+	p := &printer{}
+	p.schema(schema)
+	p.directives(schema.Directives())
+
+	var types []graphql.Type
+	for name, t := range schema.TypeMap() {
+		if strings.HasPrefix(name, "__") || builtinScalars[name] {
+			continue
+		}
+		types = append(types, t)
+	}
+	sort.Slice(types, func(i, j int) bool {
+		ki, kj := kindOrder(types[i]), kindOrder(types[j])
+		if ki != kj {
+			return ki < kj
+		}
+		return types[i].Name() < types[j].Name()
+	})
+	for _, t := range types {
+		p.namedType(t)
+	}
+	return strings.Join(p.blocks, "\n")
+}
+
+// kindOrder ranks the kinds of types in the order they are printed.
+func kindOrder(t graphql.Type) int {
+	switch t.(type) {
+	case *graphql.Scalar:
+		return 0
+	case *graphql.Enum:
+		return 1
+	case *graphql.Interface:
+		return 2
+	case *graphql.Union:
+		return 3
+	case *graphql.Object:
+		return 4
+	case *graphql.InputObject:
+		return 5
+	}
+	return 6
+}
+
+// printer accumulates the definitions of a schema, one block each.
+type printer struct {
+	blocks []string
+	b      strings.Builder
+}
+
+func (p *printer) flush() {
+	p.blocks = append(p.blocks, p.b.String())
+	p.b.Reset()
+}
+
+// schema prints the schema definition, which is omitted when the root
+// types have their conventional names.
+func (p *printer) schema(s *graphql.Schema) {
+	roots := []struct {
+		operation string
+		object    *graphql.Object
+	}{
+		{"query", s.QueryType()},
+		{"mutation", s.MutationType()},
+		{"subscription", s.SubscriptionType()},
+	}
+	conventional := true
+	for _, r := range roots {
+		if r.object != nil && r.object.Name() != strings.Title(r.operation) {
+			conventional = false
+		}
+	}
+	if conventional {
+		return
+	}
+	p.b.WriteString("schema {\n")
+	for _, r := range roots {
+		if r.object != nil {
+			fmt.Fprintf(&p.b, "  %s: %s\n", r.operation, r.object.Name())
+		}
+	}
+	p.b.WriteString("}\n")
+	p.flush()
+}
+
+// directives prints the directives that are not specified by GraphQL.
+func (p *printer) directives(directives []*graphql.Directive) {
+	specified := map[string]bool{}
+	for _, d := range graphql.SpecifiedDirectives {
+		specified[d.Name] = true
+	}
+	var custom []*graphql.Directive
+	for _, d := range directives {
+		if !specified[d.Name] {
+			custom = append(custom, d)
+		}
+	}
+	sort.Slice(custom, func(i, j int) bool { return custom[i].Name < custom[j].Name })
+	for _, d := range custom {
+		p.description(d.Description, "")
+		fmt.Fprintf(&p.b, "directive @%s%s on %s\n", d.Name, p.arguments(d.Args, ""), strings.Join(d.Locations, " | "))
+		p.flush()
+	}
+}
+
+func (p *printer) namedType(t graphql.Type) {
+	switch t := t.(type) {
+	case *graphql.Scalar:
+		p.description(t.Description(), "")
+		fmt.Fprintf(&p.b, "scalar %s\n", t.Name())
+	case *graphql.Enum:
+		p.description(t.Description(), "")
+		fmt.Fprintf(&p.b, "enum %s {\n", t.Name())
+		values := append([]*graphql.EnumValueDefinition(nil), t.Values()...)
+		sort.Slice(values, func(i, j int) bool { return values[i].Name < values[j].Name })
+		for _, v := range values {
+			p.description(v.Description, "  ")
+			fmt.Fprintf(&p.b, "  %s%s\n", v.Name, deprecated(v.DeprecationReason))
+		}
+		p.b.WriteString("}\n")
+	case *graphql.Interface:
+		p.description(t.Description(), "")
+		fmt.Fprintf(&p.b, "interface %s {\n", t.Name())
+		p.fields(t.Fields())
+		p.b.WriteString("}\n")
+	case *graphql.Union:
+		p.description(t.Description(), "")
+		var members []string
+		for _, o := range t.Types() {
+			members = append(members, o.Name())
+		}
+		sort.Strings(members)
+		fmt.Fprintf(&p.b, "union %s = %s\n", t.Name(), strings.Join(members, " | "))
+	case *graphql.Object:
+		// Object.Description always returns "" in graphql-go.
+		p.description(t.PrivateDescription, "")
+		fmt.Fprintf(&p.b, "type %s%s {\n", t.Name(), implements(t.Interfaces()))
+		p.fields(t.Fields())
+		p.b.WriteString("}\n")
+	case *graphql.InputObject:
+		p.description(t.Description(), "")
+		fmt.Fprintf(&p.b, "input %s {\n", t.Name())
+		fields := t.Fields()
+		var names []string
+		for name := range fields {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			field := fields[name]
+			p.description(field.PrivateDescription, "  ")
+			fmt.Fprintf(&p.b, "  %s: %s%s\n", name, field.Type, defaultValue(field.Type, field.DefaultValue))
+		}
+		p.b.WriteString("}\n")
+	default:
+		return
+	}
+	p.flush()
+}
+
+func (p *printer) fields(fields graphql.FieldDefinitionMap) {
+	var names []string
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		field := fields[name]
+		p.description(field.Description, "  ")
+		fmt.Fprintf(&p.b, "  %s%s: %s%s\n", name, p.arguments(field.Args, "  "), field.Type, deprecated(field.DeprecationReason))
+	}
+}
+
+// arguments returns the argument list of a field or directive, on one line
+// unless an argument has a description.
+func (p *printer) arguments(args []*graphql.Argument, indent string) string {
+	if len(args) == 0 {
+		return ""
+	}
+	args = append([]*graphql.Argument(nil), args...)
+	sort.Slice(args, func(i, j int) bool { return args[i].Name() < args[j].Name() })
+
+	multiline := false
+	for _, a := range args {
+		if a.Description() != "" {
+			multiline = true
+		}
+	}
+	var b strings.Builder
+	for i, a := range args {
+		def := a.Name() + ": " + a.Type.String() + defaultValue(a.Type, a.DefaultValue)
+		if !multiline {
+			if i > 0 {
+				b.WriteString(", ")
+			}
+			b.WriteString(def)
+			continue
+		}
+		b.WriteString("\n")
+		b.WriteString(descriptionString(a.Description(), indent+"  "))
+		b.WriteString(indent + "  " + def)
+	}
+	if multiline {
+		return "(" + b.String() + "\n" + indent + ")"
+	}
+	return "(" + b.String() + ")"
+}
+
+func (p *printer) description(s, indent string) {
+	p.b.WriteString(descriptionString(s, indent))
+}
+
+// descriptionString returns s as a description string, a block string if
+// it spans several lines, followed by a newline.
+func descriptionString(s, indent string) string {
+	if s == "" {
+		return ""
+	}
+	if !strings.Contains(s, "\n") {
+		return indent + quote(s) + "\n"
+	}
+	lines := strings.Split(strings.ReplaceAll(s, `"""`, `\"""`), "\n")
+	var b strings.Builder
+	b.WriteString(indent + `"""` + "\n")
+	for _, line := range lines {
+		if line != "" {
+			b.WriteString(indent + line)
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString(indent + `"""` + "\n")
+	return b.String()
+}
+
+// quote returns s as a GraphQL string. Unlike strconv.Quote, which writes Go
+// escapes such as \x07 and \U0001F600 that GraphQL does not have, it only
+// escapes what GraphQL requires to: quotes, backslashes and control
+// characters, the latter as \uXXXX unless they have a shorter escape.
+// Invalid UTF-8 is replaced by U+FFFD.
+func quote(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"':
+			b.WriteString(`\"`)
+		case '\\':
+			b.WriteString(`\\`)
+		case '\b':
+			b.WriteString(`\b`)
+		case '\f':
+			b.WriteString(`\f`)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\r':
+			b.WriteString(`\r`)
+		case '\t':
+			b.WriteString(`\t`)
+		default:
+			if r < 0x20 || r == 0x7f {
+				fmt.Fprintf(&b, `\u%04X`, r)
+			} else {
+				b.WriteRune(r)
+			}
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
+
+func implements(interfaces []*graphql.Interface) string {
+	if len(interfaces) == 0 {
+		return ""
+	}
+	var names []string
+	for _, i := range interfaces {
+		names = append(names, i.Name())
+	}
+	sort.Strings(names)
+	return " implements " + strings.Join(names, " & ")
+}
+
+func deprecated(reason string) string {
+	switch reason {
+	case "":
+		return ""
+	case graphql.DefaultDeprecationReason:
+		return " @deprecated"
+	}
+	return " @deprecated(reason: " + quote(reason) + ")"
+}
+
+func defaultValue(t graphql.Type, v interface{}) string {
+	if v == nil {
+		return ""
+	}
+	return " = " + literal(t, v)
+}
+
+// literal returns the GraphQL literal of v, a Go value of type t.
+func literal(t graphql.Type, v interface{}) string {
+	if v == nil {
+		return "null"
+	}
+	switch t := t.(type) {
+	case *graphql.NonNull:
+		return literal(t.OfType, v)
+	case *graphql.List:
+		items, ok := v.([]interface{})
+		if !ok {
+			// A single value is coerced to a list of one.
+			return literal(t.OfType, v)
+		}
+		var l []string
+		for _, item := range items {
+			l = append(l, literal(t.OfType, item))
+		}
+		return "[" + strings.Join(l, ", ") + "]"
+	case *graphql.Enum:
+		if s, ok := t.Serialize(v).(string); ok {
+			return s
+		}
+	case *graphql.InputObject:
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			break
+		}
+		fields := t.Fields()
+		var names []string
+		for name := range m {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		var l []string
+		for _, name := range names {
+			if field, ok := fields[name]; ok {
+				l = append(l, name+": "+literal(field.Type, m[name]))
+			}
+		}
+		return "{" + strings.Join(l, ", ") + "}"
+	case *graphql.Scalar:
+		v = t.Serialize(v)
+	}
+	switch v := v.(type) {
+	case string:
+		return quote(v)
+	case bool:
+		return strconv.FormatBool(v)
+	case float32:
+		return strconv.FormatFloat(float64(v), 'g', -1, 32)
+	case float64:
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	}
+	return fmt.Sprint(v)
+}
@@ -0,0 +1,193 @@
+package sdl
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/vektah/gqlparser/v2"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/parser"
+)
+
+func testSchema(t *testing.T) *graphql.Schema {
+	t.Helper()
+	episode := graphql.NewEnum(graphql.EnumConfig{
+		Name:        "Episode",
+		Description: "A film of the original trilogy",
+		Values: graphql.EnumValueConfigMap{
+			"NEWHOPE": &graphql.EnumValueConfig{Value: 4, Description: "Released in 1977"},
+			"JEDI":    &graphql.EnumValueConfig{Value: 6, DeprecationReason: "Ewoks"},
+			"EMPIRE":  &graphql.EnumValueConfig{Value: 5, DeprecationReason: graphql.DefaultDeprecationReason},
+		},
+	})
+	character := graphql.NewInterface(graphql.InterfaceConfig{
+		Name: "Character",
+		Fields: graphql.Fields{
+			"name": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+		},
+	})
+	droid := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Droid",
+		Description: "A mechanical character.\nBeep boop.",
+		Interfaces:  []*graphql.Interface{character},
+		Fields: graphql.Fields{
+			"name":            &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"primaryFunction": &graphql.Field{Type: graphql.String, DeprecationReason: "Use name"},
+		},
+	})
+	character.ResolveType = func(graphql.ResolveTypeParams) *graphql.Object { return droid }
+	search := graphql.NewUnion(graphql.UnionConfig{
+		Name:        "SearchResult",
+		Types:       []*graphql.Object{droid},
+		ResolveType: func(graphql.ResolveTypeParams) *graphql.Object { return droid },
+	})
+	filter := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "Filter",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"text":     &graphql.InputObjectFieldConfig{Type: graphql.String, DefaultValue: "R2", Description: "Part of the name"},
+			"episodes": &graphql.InputObjectFieldConfig{Type: graphql.NewList(episode), DefaultValue: []interface{}{4, 6}},
+		},
+	})
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Root",
+			Fields: graphql.Fields{
+				"hero": &graphql.Field{
+					Type: character,
+					Args: graphql.FieldConfigArgument{
+						"episode": &graphql.ArgumentConfig{Type: episode, DefaultValue: 4},
+					},
+				},
+				"search": &graphql.Field{
+					Type: graphql.NewList(graphql.NewNonNull(search)),
+					Args: graphql.FieldConfigArgument{
+						"filter": &graphql.ArgumentConfig{Type: filter, DefaultValue: map[string]interface{}{"text": "C-3PO"}, Description: "What to look for"},
+						"first":  &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: 10},
+					},
+				},
+			},
+		}),
+		Types: []graphql.Type{droid},
+		Directives: append(graphql.SpecifiedDirectives, graphql.NewDirective(graphql.DirectiveConfig{
+			Name:      "cost",
+			Locations: []string{graphql.DirectiveLocationField, graphql.DirectiveLocationFieldDefinition},
+			Args: graphql.FieldConfigArgument{
+				"value": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.Int)},
+			},
+		})),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &schema
+}
+
+const testSDL = `schema {
+  query: Root
+}
+
+directive @cost(value: Int!) on FIELD | FIELD_DEFINITION
+
+"A film of the original trilogy"
+enum Episode {
+  EMPIRE @deprecated
+  JEDI @deprecated(reason: "Ewoks")
+  "Released in 1977"
+  NEWHOPE
+}
+
+interface Character {
+  name: String!
+}
+
+union SearchResult = Droid
+
+"""
+A mechanical character.
+Beep boop.
+"""
+type Droid implements Character {
+  name: String!
+  primaryFunction: String @deprecated(reason: "Use name")
+}
+
+type Root {
+  hero(episode: Episode = NEWHOPE): Character
+  search(
+    "What to look for"
+    filter: Filter = {text: "C-3PO"}
+    first: Int = 10
+  ): [SearchResult!]
+}
+
+input Filter {
+  episodes: [Episode] = [NEWHOPE, JEDI]
+  "Part of the name"
+  text: String = "R2"
+}
+`
+
+func TestPrint(t *testing.T) {
+	got := Print(testSchema(t))
+	if got != testSDL {
+		t.Errorf("got\n%s\nwant\n%s", got, testSDL)
+	}
+	if _, err := gqlparser.LoadSchema(&ast.Source{Input: got}); err != nil {
+		t.Errorf("the SDL printed does not load: %v", err)
+	}
+}
+
+func TestPrintStable(t *testing.T) {
+	want := Print(testSchema(t))
+	for i := 0; i < 10; i++ {
+		if got := Print(testSchema(t)); got != want {
+			t.Fatalf("got\n%s\nafter\n%s", got, want)
+		}
+	}
+}
+
+func TestHandler(t *testing.T) {
+	schema := testSchema(t)
+	rec := httptest.NewRecorder()
+	Handler(schema).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/schema.graphql", nil))
+	if got := rec.Header().Get("Content-Type"); got != "text/plain; charset=utf-8" {
+		t.Errorf("got content type %q, want text/plain", got)
+	}
+	if body, _ := ioutil.ReadAll(rec.Body); string(body) != Print(schema) {
+		t.Errorf("got body\n%s\nwant the SDL of the schema", body)
+	}
+}
+
+func TestQuote(t *testing.T) {
+	tests := []struct {
+		s    string
+		want string
+	}{
+		{`plain`, `"plain"`},
+		{`say "hi" \o/`, `"say \"hi\" \\o/"`},
+		{"tab\tnew line\ncarriage\rback\bfeed\f", `"tab\tnew line\ncarriage\rback\bfeed\f"`},
+		{"bell\a nul\x00 esc\x1b del\x7f", `"bell\u0007 nul\u0000 esc\u001B del\u007F"`},
+		{"Naïve 😀", `"Naïve 😀"`},
+		{"bad \xff byte", "\"bad \uFFFD byte\""},
+	}
+	for _, test := range tests {
+		got := quote(test.s)
+		if got != test.want {
+			t.Errorf("quote(%q) = %s, want %s", test.s, got, test.want)
+			continue
+		}
+		// The string reads back as s, but for invalid UTF-8.
+		doc, err := parser.ParseQuery(&ast.Source{Input: `{ f(s: ` + got + `) }`})
+		if err != nil {
+			t.Errorf("quote(%q) = %s does not parse: %v", test.s, got, err)
+			continue
+		}
+		value := doc.Operations[0].SelectionSet[0].(*ast.Field).Arguments[0].Value.Raw
+		if want := string([]rune(test.s)); value != want {
+			t.Errorf("quote(%q) = %s reads back as %q", test.s, got, value)
+		}
+	}
+}